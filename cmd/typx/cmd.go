@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// exit codes
+const (
+	succeeded = 0
+	failed    = 1
+	invalid   = 2
+)
+
+var errUsage = errors.New("invalid usage")
+
+// command runs with parsed flags and arguments
+type command struct {
+	name  string
+	usage string
+	flags func(fs *flag.FlagSet)
+	run   func(o *output, args []string) (int, error)
+}
+
+// output carries output options of command
+type output struct {
+	json   bool
+	stdout io.Writer
+}
+
+// print writes v as indented json or the text returned by text
+func (o *output) print(v any, text func() string) error {
+	if o.json {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(o.stdout, string(data))
+		return err
+	}
+	_, err := io.WriteString(o.stdout, text())
+	return err
+}
+
+// commands returns the commands, options of commands are not shared between
+// runs
+func commands() []*command {
+	return []*command{show, newLit(), implements}
+}
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: typx [-json] <command> [flags] <args>")
+	_, _ = fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands() {
+		_, _ = fmt.Fprintf(w, "  %s\n", cmd.usage)
+	}
+}
+
+// run runs command line args and returns exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("typx", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { usage(stderr) }
+	asJSON := fs.Bool("json", false, "output as json")
+	if err := fs.Parse(args); err != nil {
+		return invalid
+	}
+	if fs.NArg() == 0 {
+		usage(stderr)
+		return invalid
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name != fs.Arg(0) {
+			continue
+		}
+		o := &output{stdout: stdout}
+		sub := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		sub.SetOutput(stderr)
+		sub.Usage = func() { _, _ = fmt.Fprintf(stderr, "usage: typx %s\n", cmd.usage) }
+		sub.BoolVar(&o.json, "json", *asJSON, "output as json")
+		if cmd.flags != nil {
+			cmd.flags(sub)
+		}
+		if err := sub.Parse(fs.Args()[1:]); err != nil {
+			return invalid
+		}
+		code, err := cmd.run(o, sub.Args())
+		if errors.Is(err, errUsage) {
+			sub.Usage()
+			return invalid
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "typx %s: %v\n", cmd.name, err)
+			return invalid
+		}
+		return code
+	}
+
+	_, _ = fmt.Fprintf(stderr, "typx: unknown command %q\n", fs.Arg(0))
+	usage(stderr)
+	return invalid
+}
+
+// resolve resolves type by spec, eg: `time.Time`, `*bytes.Buffer` and
+// `example.com/x.Page[map[string][]int]`. predeclared types are specified by
+// name. the spec is parsed as Go type expression after the import paths are
+// replaced by placeholder package names.
+func resolve(spec string) (typx.Type, error) {
+	paths := make([]string, 0)
+	src := qualified.ReplaceAllStringFunc(spec, func(s string) string {
+		i := strings.LastIndexByte(s, '.')
+		paths = append(paths, s[:i])
+		return fmt.Sprintf("_%d%s", len(paths)-1, s[i:])
+	})
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %w", spec, err)
+	}
+	t, err := (&resolver{paths: paths}).resolve(expr)
+	if err != nil {
+		return nil, err
+	}
+	return typx.NewTType(t), nil
+}
+
+// qualified matches qualified type name, eg: `example.com/x.Page`
+var qualified = regexp.MustCompile(`[\w\-~](?:[\w\-~/.]*[\w\-~])?\.[\pL_][\pL\pN_]*`)
+
+// resolver resolves parsed type expression
+type resolver struct {
+	// paths are import paths indexed by placeholder package names
+	paths []string
+}
+
+func (r *resolver) resolve(expr ast.Expr) (types.Type, error) {
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return r.resolve(x.X)
+	case *ast.Ident:
+		obj, ok := types.Universe.Lookup(x.Name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("undefined type %q", x.Name)
+		}
+		return obj.Type(), nil
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("invalid type %s", types.ExprString(x))
+		}
+		i, err := strconv.Atoi(strings.TrimPrefix(pkg.Name, "_"))
+		if err != nil || i >= len(r.paths) {
+			return nil, fmt.Errorf("invalid type %s", types.ExprString(x))
+		}
+		p, err := typx.Load(r.paths[i])
+		if err != nil {
+			return nil, err
+		}
+		t, ok := p.Type(x.Sel.Name)
+		if !ok {
+			return nil, fmt.Errorf("undefined type %q in package %s", x.Sel.Name, p.Path())
+		}
+		return t.Unwrap().(types.Type), nil
+	case *ast.StarExpr:
+		elem, err := r.resolve(x.X)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+	case *ast.ArrayType:
+		elem, err := r.resolve(x.Elt)
+		if err != nil {
+			return nil, err
+		}
+		if x.Len == nil {
+			return types.NewSlice(elem), nil
+		}
+		lit, ok := x.Len.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return nil, fmt.Errorf("invalid array length %s", types.ExprString(x.Len))
+		}
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array length %s", lit.Value)
+		}
+		return types.NewArray(elem, n), nil
+	case *ast.MapType:
+		key, err := r.resolve(x.Key)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := r.resolve(x.Value)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMap(key, elem), nil
+	case *ast.ChanType:
+		elem, err := r.resolve(x.Value)
+		if err != nil {
+			return nil, err
+		}
+		dir := types.SendRecv
+		switch x.Dir {
+		case ast.SEND:
+			dir = types.SendOnly
+		case ast.RECV:
+			dir = types.RecvOnly
+		}
+		return types.NewChan(dir, elem), nil
+	case *ast.FuncType:
+		params, variadic, err := r.tuple(x.Params)
+		if err != nil {
+			return nil, err
+		}
+		results, _, err := r.tuple(x.Results)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSignatureType(nil, nil, nil, params, results, variadic), nil
+	case *ast.InterfaceType:
+		if len(x.Methods.List) > 0 {
+			return nil, fmt.Errorf("unsupported type %s", types.ExprString(x))
+		}
+		return types.NewInterfaceType(nil, nil), nil
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		base, indices := x, []ast.Expr(nil)
+		if ix, ok := x.(*ast.IndexExpr); ok {
+			base, indices = ix.X, []ast.Expr{ix.Index}
+		} else {
+			ix := x.(*ast.IndexListExpr)
+			base, indices = ix.X, ix.Indices
+		}
+		generic, err := r.resolve(base)
+		if err != nil {
+			return nil, err
+		}
+		targs := make([]typx.Type, len(indices))
+		for i, index := range indices {
+			arg, err := r.resolve(index)
+			if err != nil {
+				return nil, err
+			}
+			targs[i] = typx.NewTType(arg)
+		}
+		t, err := typx.Instantiate(typx.NewTType(generic), targs...)
+		if err != nil {
+			return nil, err
+		}
+		return t.Unwrap().(types.Type), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", types.ExprString(expr))
+	}
+}
+
+// tuple resolves parameters or results of function type
+func (r *resolver) tuple(fields *ast.FieldList) (*types.Tuple, bool, error) {
+	if fields == nil {
+		return nil, false, nil
+	}
+	vars, variadic := make([]*types.Var, 0), false
+	for _, f := range fields.List {
+		expr := f.Type
+		if ellipsis, ok := expr.(*ast.Ellipsis); ok {
+			expr, variadic = &ast.ArrayType{Elt: ellipsis.Elt}, true
+		}
+		t, err := r.resolve(expr)
+		if err != nil {
+			return nil, false, err
+		}
+		for range max(len(f.Names), 1) {
+			vars = append(vars, types.NewParam(token.NoPos, nil, "", t))
+		}
+	}
+	return types.NewTuple(vars...), variadic, nil
+}
+
+// uninstantiated reports if t is a generic type without type arguments
+func uninstantiated(t typx.Type) bool {
+	n, ok := t.Unwrap().(*types.Named)
+	return ok && n.TypeParams().Len() > n.TypeArgs().Len()
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+)
+
+const path = "github.com/xoctopus/typx/testdata"
+
+func exec(args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(args, stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestShow(t *testing.T) {
+	code, stdout, _ := exec("show", "*"+path+".Rect")
+	Expect(t, code, Equal(succeeded))
+	Expect(t, stdout, Equal("type    *"+path+".Rect\n"+
+		"kind    ptr\n"+
+		"literal *"+path+".Rect\n"+
+		"method set of T: 1\n"+
+		"  Area func(*"+path+".Rect) float64\n"))
+
+	code, stdout, _ = exec("show", path+".Rect")
+	Expect(t, code, Equal(succeeded))
+	Expect(t, strings.Contains(stdout, "  Width  float64 `json:\"width\"`\n"), BeTrue())
+	Expect(t, strings.Contains(stdout, "method set of T: 0\nmethod set of *T: 1\n"), BeTrue())
+
+	code, stdout, _ = exec("-json", "show", path+".Page["+path+".Circle]")
+	Expect(t, code, Equal(succeeded))
+	d := &description{}
+	Expect(t, json.Unmarshal([]byte(stdout), d), BeNil[error]())
+	Expect(t, d.Kind, Equal("struct"))
+	Expect(t, len(d.Fields), Equal(3))
+	Expect(t, d.Fields[0].Type, Equal("[]"+path+".Circle"))
+	Expect(t, d.Fields[2].Tag, Equal(`json:"next,omitempty"`))
+
+	for _, args := range [][]string{
+		{"show"},
+		{"show", path + ".Page"},
+		{"show", path + ".Undefined"},
+		{"show", "undefined"},
+		{"show", path + ".Page[int,int]"},
+	} {
+		code, _, stderr := exec(args...)
+		Expect(t, code, Equal(invalid))
+		Expect(t, stderr, NotEqual(""))
+	}
+}
+
+func TestLit(t *testing.T) {
+	for namer, expect := range map[string]string{
+		"path": path + ".Page[" + path + ".Circle]\ntime.Time\n",
+		"wrap": "github_com_xoctopus_typx_testdata.Page[github_com_xoctopus_typx_testdata.Circle]\ntime.Time\n",
+		"name": "testdata.Page[testdata.Circle]\ntime.Time\n",
+	} {
+		code, stdout, _ := exec("lit", "-namer", namer, path+".Page["+path+".Circle]", "time.Time")
+		Expect(t, code, Equal(succeeded))
+		Expect(t, stdout, Equal(expect))
+	}
+
+	code, stdout, _ := exec("lit", "-json", "-namer", "name", "-local", path, path+".Page[int]")
+	Expect(t, code, Equal(succeeded))
+	Expect(t, stdout, Equal("[\n  {\n    \"type\": \""+path+".Page[int]\",\n    \"literal\": \"Page[int]\"\n  }\n]\n"))
+
+	code, _, _ = exec("lit", "-namer", "unknown", "int")
+	Expect(t, code, Equal(invalid))
+	code, _, _ = exec("lit")
+	Expect(t, code, Equal(invalid))
+}
+
+func TestImplements(t *testing.T) {
+	code, stdout, _ := exec("implements", "*"+path+".Rect", path+".Shape")
+	Expect(t, code, Equal(succeeded))
+	Expect(t, stdout, Equal("*"+path+".Rect implements "+path+".Shape\n"))
+
+	code, stdout, _ = exec("implements", path+".Rect", path+".Shape")
+	Expect(t, code, Equal(failed))
+	Expect(t, stdout, Equal(path+".Rect does not implement "+path+".Shape\n  Area: method has pointer receiver\n"))
+
+	code, stdout, _ = exec("--json", "implements", path+".Circle", "fmt.Stringer")
+	Expect(t, code, Equal(failed))
+	r := &implementation{}
+	Expect(t, json.Unmarshal([]byte(stdout), r), BeNil[error]())
+	Expect(t, r.Implements, BeFalse())
+	Expect(t, len(r.Missing), Equal(1))
+	Expect(t, *r.Missing[0], Equal(missingMethod{Name: "String", Reason: "missing method"}))
+
+	for _, args := range [][]string{
+		{"implements", path + ".Rect"},
+		{"implements", path + ".Rect", path + ".Circle"},
+		{"implements", path + ".Undefined", "error"},
+		{"implements", "error", path + ".Undefined"},
+	} {
+		code, _, _ := exec(args...)
+		Expect(t, code, Equal(invalid))
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"-unknown"},
+		{"unknown"},
+		{"show", "-unknown"},
+	} {
+		code, _, stderr := exec(args...)
+		Expect(t, code, Equal(invalid))
+		Expect(t, strings.Contains(stderr, "usage: typx"), BeTrue())
+	}
+}
+
+func TestResolve(t *testing.T) {
+	for spec, expect := range map[string]string{
+		"int":                              "int",
+		"*bytes.Buffer":                    "*bytes.Buffer",
+		"[]time.Time":                      "[]time.Time",
+		path + ".Page[[]int]":              path + ".Page[[]int]",
+		path + ".Page[map[string]int]":     path + ".Page[map[string]int]",
+		path + ".Page[func(int) error]":    path + ".Page[func(int) error]",
+		path + ".Page[*" + path + ".Rect]": path + ".Page[*" + path + ".Rect]",
+		path + ".Page[[2]<-chan any]":      path + ".Page[[2]<-chan interface {}]",
+		"func(x, y int, z ...time.Time) (" + path + ".Page[string], error)": "func(int, int, ...time.Time) (" + path + ".Page[string], error)",
+	} {
+		x, err := resolve(spec)
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(expect))
+	}
+
+	for _, spec := range []string{
+		"undefined",
+		"time.Undefined",
+		path + ".Page[",
+		"[n]int",
+		// type literals with members are not supported
+		"interface{ M() }",
+		path + ".Page[struct{}]",
+	} {
+		_, err := resolve(spec)
+		Expect(t, err, NotBeNil[error]())
+	}
+}
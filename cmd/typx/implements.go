@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+var implements = &command{
+	name:  "implements",
+	usage: "implements <T> <Iface>",
+	run: func(o *output, args []string) (int, error) {
+		if len(args) != 2 {
+			return invalid, errUsage
+		}
+		t, err := resolve(args[0])
+		if err != nil {
+			return invalid, err
+		}
+		iface, err := resolve(args[1])
+		if err != nil {
+			return invalid, err
+		}
+		if iface.Kind() != reflect.Interface {
+			return invalid, fmt.Errorf("%s is not an interface", iface)
+		}
+
+		r := &implementation{
+			Type:       t.String(),
+			Interface:  iface.String(),
+			Implements: t.Implements(iface),
+		}
+		if !r.Implements {
+			r.Missing = missing(t.Unwrap().(types.Type), iface.Unwrap().(types.Type))
+		}
+		code := succeeded
+		if !r.Implements {
+			code = failed
+		}
+		return code, o.print(r, r.String)
+	},
+}
+
+// implementation is the result of implements command
+type implementation struct {
+	Type       string           `json:"type"`
+	Interface  string           `json:"interface"`
+	Implements bool             `json:"implements"`
+	Missing    []*missingMethod `json:"missing,omitempty"`
+}
+
+// missingMethod is a method of interface which is not implemented
+type missingMethod struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// missing explains why methods of iface are not implemented by t
+func missing(t, iface types.Type) []*missingMethod {
+	ms := make([]*missingMethod, 0)
+	it := iface.Underlying().(*types.Interface)
+	for i := range it.NumMethods() {
+		m := it.Method(i)
+		want := m.Type().(*types.Signature)
+
+		obj, _, _ := types.LookupFieldOrMethod(t, false, m.Pkg(), m.Name())
+		f, ok := obj.(*types.Func)
+		switch {
+		case ok && types.Identical(f.Type().(*types.Signature), want):
+			continue
+		case ok:
+			ms = append(ms, &missingMethod{
+				Name:   m.Name(),
+				Reason: fmt.Sprintf("wrong type, have %s, want %s", signature(f), signature(m)),
+			})
+		case obj != nil:
+			ms = append(ms, &missingMethod{Name: m.Name(), Reason: "field is not a method"})
+		default:
+			ptr, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), false, m.Pkg(), m.Name())
+			if f, ok := ptr.(*types.Func); ok && types.Identical(f.Type().(*types.Signature), want) {
+				ms = append(ms, &missingMethod{Name: m.Name(), Reason: "method has pointer receiver"})
+			} else {
+				ms = append(ms, &missingMethod{Name: m.Name(), Reason: "missing method"})
+			}
+		}
+	}
+	return ms
+}
+
+// signature returns method f declared without receiver, eg: `Area() float64`
+func signature(f *types.Func) string {
+	sig := f.Type().(*types.Signature)
+	sig = types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic())
+	return f.Name() + strings.TrimPrefix(types.TypeString(sig, nil), "func")
+}
+
+func (r *implementation) String() string {
+	if r.Implements {
+		return fmt.Sprintf("%s implements %s\n", r.Type, r.Interface)
+	}
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("%s does not implement %s\n", r.Type, r.Interface))
+	for _, m := range r.Missing {
+		b.WriteString(fmt.Sprintf("  %s: %s\n", m.Name, m.Reason))
+	}
+	return b.String()
+}
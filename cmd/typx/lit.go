@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// litOptions are the flags of lit command
+type litOptions struct {
+	// namer names packages in literal
+	//   path: import path, eg: `github.com/xoctopus/typx/testdata.Page`
+	//   wrap: wrapped import path, eg: `github_com_xoctopus_typx_testdata.Page`
+	//   name: unique package name, eg: `testdata.Page`
+	namer string
+	// local is the package path whose types are not qualified by name namer
+	local string
+}
+
+// newLit creates lit command with its own options
+func newLit() *command {
+	opts := &litOptions{}
+	return &command{
+		name:  "lit",
+		usage: "lit [-namer path|wrap|name] [-local <pkg>] <type>...",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&opts.namer, "namer", "path", "package naming: path, wrap or name")
+			fs.StringVar(&opts.local, "local", "", "local package path for name namer")
+		},
+		run: opts.run,
+	}
+}
+
+func (opts *litOptions) run(o *output, args []string) (int, error) {
+	if len(args) == 0 {
+		return invalid, errUsage
+	}
+
+	ctx := context.Background()
+	switch opts.namer {
+	case "path":
+	case "wrap":
+		ctx = typx.CtxWrapID.With(ctx, true)
+	case "name":
+		ctx = typx.CtxPkgNamer.With(ctx, codegen.NewImports(opts.local))
+	default:
+		return invalid, fmt.Errorf("unknown namer %q", opts.namer)
+	}
+
+	literals := make([]*literal, 0, len(args))
+	for _, arg := range args {
+		t, err := resolve(arg)
+		if err != nil {
+			return invalid, err
+		}
+		literals = append(literals, &literal{Type: arg, Literal: typx.TypeLit(ctx, t.Unwrap())})
+	}
+	return succeeded, o.print(literals, func() string {
+		b := strings.Builder{}
+		for _, x := range literals {
+			b.WriteString(x.Literal + "\n")
+		}
+		return b.String()
+	})
+}
+
+type literal struct {
+	Type    string `json:"type"`
+	Literal string `json:"literal"`
+}
@@ -0,0 +1,26 @@
+// Command typx inspects types of packages in the local module.
+//
+// Usage:
+//
+//	typx [-json] <command> [flags] <args>
+//
+// The commands are:
+//
+//	show <pkg>.<Type>       dump literal, kind, fields and method sets of T and *T
+//	lit <type>...           print type literals, -namer names packages by
+//	                        import path(default), wrapped path or unique name
+//	implements <T> <Iface>  check if T implements Iface and explain the missing
+//	                        methods, it exits with 1 if not implemented
+//
+// Types are specified by import path and name, eg: `time.Time`, generic types
+// are instantiated with type arguments, eg: `example.com/x.Page[int]`, and `*`
+// prefix specifies pointer type.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+var show = &command{
+	name:  "show",
+	usage: "show <pkg>.<Type>",
+	run: func(o *output, args []string) (int, error) {
+		if len(args) != 1 {
+			return invalid, errUsage
+		}
+		t, err := resolve(args[0])
+		if err != nil {
+			return invalid, err
+		}
+		if uninstantiated(t) {
+			return invalid, fmt.Errorf("%s is uninstantiated, specify its type arguments", t)
+		}
+		s := describe(t)
+		return succeeded, o.print(s, s.String)
+	},
+}
+
+// description describes a type
+type description struct {
+	Type    string       `json:"type"`
+	Kind    string       `json:"kind"`
+	Literal string       `json:"literal"`
+	Fields  []*fieldDesc `json:"fields,omitempty"`
+	// Methods is the method set of T
+	Methods []*methodDesc `json:"methods"`
+	// PtrMethods is the method set of *T, it is omitted if T is a pointer
+	// or an interface
+	PtrMethods []*methodDesc `json:"ptrMethods,omitempty"`
+}
+
+type fieldDesc struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Tag      string `json:"tag,omitempty"`
+	Embedded bool   `json:"embedded,omitempty"`
+}
+
+type methodDesc struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func describe(t typx.Type) *description {
+	u := t.Unwrap().(types.Type)
+	d := &description{
+		Type:    t.String(),
+		Kind:    t.Kind().String(),
+		Literal: typx.TypeLit(context.Background(), u.Underlying()),
+		Methods: methods(t),
+	}
+	if t.Kind() == reflect.Struct {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			d.Fields = append(d.Fields, &fieldDesc{
+				Name:     f.Name(),
+				Type:     f.Type().String(),
+				Tag:      string(f.Tag()),
+				Embedded: f.Anonymous(),
+			})
+		}
+	}
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		d.PtrMethods = methods(typx.NewTType(types.NewPointer(u)))
+	}
+	return d
+}
+
+func methods(t typx.Type) []*methodDesc {
+	ms := make([]*methodDesc, 0, t.NumMethod())
+	for i := range t.NumMethod() {
+		m := t.Method(i)
+		ms = append(ms, &methodDesc{Name: m.Name(), Type: m.Type().String()})
+	}
+	return ms
+}
+
+func (d *description) String() string {
+	b := &strings.Builder{}
+	w := tabwriter.NewWriter(b, 0, 4, 1, ' ', 0)
+	_, _ = fmt.Fprintf(w, "type\t%s\n", d.Type)
+	_, _ = fmt.Fprintf(w, "kind\t%s\n", d.Kind)
+	_, _ = fmt.Fprintf(w, "literal\t%s\n", d.Literal)
+	_ = w.Flush()
+
+	if len(d.Fields) > 0 {
+		b.WriteString("fields:\n")
+		for _, f := range d.Fields {
+			name := f.Name
+			if f.Embedded {
+				name = "(embedded)"
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s", name, f.Type)
+			if f.Tag != "" {
+				_, _ = fmt.Fprintf(w, "\t`%s`", f.Tag)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		_ = w.Flush()
+	}
+
+	methodSet := func(name string, ms []*methodDesc) {
+		_, _ = fmt.Fprintf(b, "method set of %s: %d\n", name, len(ms))
+		for _, m := range ms {
+			_, _ = fmt.Fprintf(w, "  %s\t%s\n", m.Name, m.Type)
+		}
+		_ = w.Flush()
+	}
+	methodSet("T", d.Methods)
+	if d.PtrMethods != nil {
+		methodSet("*T", d.PtrMethods)
+	}
+	return b.String()
+}
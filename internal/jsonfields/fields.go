@@ -0,0 +1,190 @@
+// Package jsonfields selects the fields of struct encoded by encoding/json. it
+// is shared by the packages following the field visibility rules of
+// encoding/json, which inspect types of typx.Type.
+package jsonfields
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Type is the struct type inspected, eg: typx.Type
+type Type[T any, F StructField[T]] interface {
+	String() string
+	Name() string
+	Kind() reflect.Kind
+	Elem() T
+	NumField() int
+	Field(int) F
+}
+
+// StructField is the field of Type, eg: typx.StructField
+type StructField[T any] interface {
+	Name() string
+	PkgPath() string
+	Anonymous() bool
+	Tag() reflect.StructTag
+	Type() T
+}
+
+// Field is a json encoded field of struct
+type Field[T any] struct {
+	// Name is the json name, it is the Go name if not tagged
+	Name   string
+	Tagged bool
+	// Index is the index sequence of field from the inspected struct
+	Index []int
+	// Path is the selector of field, eg: `Base.ID`
+	Path string
+	// Type is the declared type of field
+	Type T
+	// Omit reports field is tagged with omitempty or omitzero
+	Omit bool
+	// Quoted reports field is encoded as json string by `string` option
+	Quoted bool
+}
+
+// Depth returns the depth of embedding, 0 for the fields of inspected struct
+func (f *Field[T]) Depth() int {
+	return len(f.Index) - 1
+}
+
+// All returns the json fields of struct t in breadth-first order, embedded
+// structs without json name are flattened as encoding/json does. the fields of
+// the same name may conflict, see Fields.
+func All[T Type[T, F], F StructField[T]](t T) []Field[T] {
+	type embedded struct {
+		t     T
+		index []int
+		path  string
+	}
+	var (
+		all     []Field[T]
+		next    = []embedded{{t: t}}
+		visited = make(map[string]bool)
+	)
+	for len(next) > 0 {
+		current := next
+		next = nil
+		// the struct embedded multiple times at the same depth is flattened
+		// at each place, so that its fields conflict with each other
+		current = slices.DeleteFunc(current, func(e embedded) bool { return visited[e.t.String()] })
+		for _, e := range current {
+			visited[e.t.String()] = true
+		}
+		for _, e := range current {
+			for i := range e.t.NumField() {
+				sf := e.t.Field(i)
+				ft := sf.Type()
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous() {
+					if sf.PkgPath() != "" && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if sf.PkgPath() != "" {
+					continue
+				}
+
+				tag := sf.Tag().Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				if !valid(name) {
+					name = ""
+				}
+				index := append(slices.Clone(e.index), i)
+				path := sf.Name()
+				if e.path != "" {
+					path = e.path + "." + path
+				}
+
+				if name != "" || !sf.Anonymous() || ft.Kind() != reflect.Struct {
+					f := Field[T]{Name: name, Tagged: name != "", Index: index, Path: path, Type: sf.Type()}
+					if f.Name == "" {
+						f.Name = sf.Name()
+					}
+					for _, opt := range strings.Split(opts, ",") {
+						switch opt {
+						case "omitempty", "omitzero":
+							f.Omit = true
+						case "string":
+							switch ft.Kind() {
+							case reflect.Bool,
+								reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+								reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+								reflect.Float32, reflect.Float64,
+								reflect.String:
+								f.Quoted = true
+							}
+						}
+					}
+					all = append(all, f)
+					continue
+				}
+				next = append(next, embedded{t: ft, index: index, path: path})
+			}
+		}
+	}
+	return all
+}
+
+// Fields returns the json encoded fields of struct t in index order. among the
+// fields of the same name, the dominant one has the shallowest depth, and is
+// the only tagged one if there are multiple fields at the same depth. the
+// conflicting fields without dominant one are ignored as encoding/json does.
+func Fields[T Type[T, F], F StructField[T]](t T) []Field[T] {
+	all := All(t)
+	slices.SortStableFunc(all, func(a, b Field[T]) int {
+		return cmp.Or(
+			strings.Compare(a.Name, b.Name),
+			cmp.Compare(len(a.Index), len(b.Index)),
+			compareBool(b.Tagged, a.Tagged),
+		)
+	})
+
+	dominants := make([]Field[T], 0, len(all))
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].Name == all[i].Name {
+			j++
+		}
+		group := all[i:j]
+		i = j
+		if len(group) > 1 && len(group[0].Index) == len(group[1].Index) && group[0].Tagged == group[1].Tagged {
+			continue
+		}
+		dominants = append(dominants, group[0])
+	}
+
+	slices.SortFunc(dominants, func(a, b Field[T]) int { return slices.Compare(a.Index, b.Index) })
+	return dominants
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// valid reports if json tag name is valid as encoding/json does
+func valid(name string) bool {
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
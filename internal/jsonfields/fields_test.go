@@ -0,0 +1,89 @@
+package jsonfields_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/internal/jsonfields"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+type Base struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type Meta struct {
+	ID      string `json:"id"`
+	Version int    `json:",string"`
+}
+
+type Label struct {
+	Label string
+}
+
+type Entity struct {
+	*Base
+	Meta
+	Label `json:"label"`
+	Title string `json:"Label"`
+	skip  string
+	Note  string `json:"-"`
+}
+
+func TestFields(t *testing.T) {
+	names := func(fields []jsonfields.Field[typx.Type]) []string {
+		s := make([]string, len(fields))
+		for i, f := range fields {
+			s[i] = f.Path + ":" + f.Name
+		}
+		return s
+	}
+
+	e := typx.NewRType(reflect.TypeFor[Entity]())
+	all := jsonfields.All(e)
+	Expect(t, names(all), Equal([]string{
+		"Label:label", "Title:Label",
+		"Base.ID:id", "Base.Name:name", "Meta.ID:id", "Meta.Version:Version",
+	}))
+	Expect(t, all[2].Depth(), Equal(1))
+
+	// Base.ID and Meta.ID conflict at the same depth
+	fields := jsonfields.Fields(e)
+	Expect(t, names(fields), Equal([]string{
+		"Base.Name:name", "Meta.Version:Version", "Label:label", "Title:Label",
+	}))
+	Expect(t, fields[0].Index, Equal([]int{0, 1}))
+	Expect(t, fields[0].Omit, BeTrue())
+	Expect(t, fields[1].Quoted, BeTrue())
+	Expect(t, fields[2].Type.String(), Equal("github.com/xoctopus/typx/internal/jsonfields_test.Label"))
+}
+
+func TestOfStruct(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", `package p
+type Base struct { ID int `+"`json:\"id,string\"`"+` }
+type Page[T any] struct {
+	*Base
+	Items []T `+"`json:\"items\"`"+`
+	total int
+}`, 0)
+	Expect(t, err, BeNil[error]())
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{f}, nil)
+	Expect(t, err, BeNil[error]())
+
+	s := pkg.Scope().Lookup("Page").Type().Underlying().(*types.Struct)
+	fields := jsonfields.OfStruct(s)
+	Expect(t, len(fields), Equal(2))
+	Expect(t, fields[0].Name, Equal("id"))
+	Expect(t, fields[0].Path, Equal("Base.ID"))
+	Expect(t, fields[0].Quoted, BeTrue())
+	Expect(t, fields[1].Name, Equal("items"))
+	Expect(t, fields[1].Type.String(), Equal("[]T"))
+}
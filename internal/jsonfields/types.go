@@ -0,0 +1,107 @@
+package jsonfields
+
+import (
+	"go/types"
+	"reflect"
+)
+
+// OfStruct returns the json encoded fields of go/types struct s, see Fields.
+// the fields can be typed by type parameters, which typx.Type cannot express.
+func OfStruct(s *types.Struct) []Field[types.Type] {
+	fields := Fields(ttype{s})
+	results := make([]Field[types.Type], len(fields))
+	for i, f := range fields {
+		results[i] = Field[types.Type]{
+			Name:   f.Name,
+			Tagged: f.Tagged,
+			Index:  f.Index,
+			Path:   f.Path,
+			Type:   f.Type.t,
+			Omit:   f.Omit,
+			Quoted: f.Quoted,
+		}
+	}
+	return results
+}
+
+// ttype implements Type by go/types
+type ttype struct {
+	t types.Type
+}
+
+func (t ttype) String() string {
+	return types.TypeString(t.t, nil)
+}
+
+func (t ttype) Name() string {
+	if n, ok := types.Unalias(t.t).(*types.Named); ok {
+		return n.Obj().Name()
+	}
+	return ""
+}
+
+func (t ttype) Kind() reflect.Kind {
+	switch u := types.Unalias(t.t).Underlying().(type) {
+	case *types.Pointer:
+		return reflect.Pointer
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			return reflect.Bool
+		case info&types.IsInteger != 0:
+			return reflect.Int
+		case info&types.IsFloat != 0:
+			return reflect.Float64
+		case info&types.IsString != 0:
+			return reflect.String
+		}
+	case *types.Interface:
+		return reflect.Interface
+	}
+	// the other kinds are not distinguished by json fields
+	return reflect.Invalid
+}
+
+func (t ttype) Elem() ttype {
+	return ttype{types.Unalias(t.t).Underlying().(*types.Pointer).Elem()}
+}
+
+func (t ttype) NumField() int {
+	return types.Unalias(t.t).Underlying().(*types.Struct).NumFields()
+}
+
+func (t ttype) Field(i int) tfield {
+	s := types.Unalias(t.t).Underlying().(*types.Struct)
+	return tfield{v: s.Field(i), tag: reflect.StructTag(s.Tag(i))}
+}
+
+// tfield implements StructField by go/types
+type tfield struct {
+	v   *types.Var
+	tag reflect.StructTag
+}
+
+func (f tfield) Name() string {
+	return f.v.Name()
+}
+
+func (f tfield) PkgPath() string {
+	if f.v.Exported() {
+		return ""
+	}
+	return f.v.Pkg().Path()
+}
+
+func (f tfield) Anonymous() bool {
+	return f.v.Anonymous()
+}
+
+func (f tfield) Tag() reflect.StructTag {
+	return f.tag
+}
+
+func (f tfield) Type() ttype {
+	return ttype{f.v.Type()}
+}
@@ -1,6 +1,7 @@
 package typx
 
 import (
+	"fmt"
 	"go/types"
 	"reflect"
 
@@ -91,3 +92,14 @@ func NewTTByLit(t *LitType) types.Type {
 func NewTTByRT(r reflect.Type) types.Type {
 	return NewTTByLit(NewLitTypeByID(wrapRT(r)))
 }
+
+// TryNewTTByRT is like NewTTByRT, but returns error instead of panicking if r
+// cannot be loaded from source, eg: r is declared in test files or main package
+func TryNewTTByRT(r reflect.Type) (t types.Type, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("failed to convert %s to types.Type: %v", r, v)
+		}
+	}()
+	return NewTTByRT(r), nil
+}
@@ -1,6 +1,7 @@
 package typx_test
 
 import (
+	"reflect"
 	"testing"
 
 	. "github.com/xoctopus/x/testx"
@@ -15,3 +16,19 @@ func TestNewTTByRT(t *testing.T) {
 		Expect(t, fromRT, Equal(fromTT))
 	}
 }
+
+func TestTryNewTTByRT(t *testing.T) {
+	x, err := typx.TryNewTTByRT(reflect.TypeFor[[]int]())
+	Expect(t, err, BeNil[error]())
+	Expect(t, x.String(), Equal("[]int"))
+
+	// declared in function scope cannot be loaded
+	type local struct{ Name string }
+	for _, r := range []reflect.Type{
+		reflect.TypeFor[local](),
+		reflect.TypeFor[map[string]*local](),
+	} {
+		_, err = typx.TryNewTTByRT(r)
+		Expect(t, err, NotBeNil[error]())
+	}
+}
@@ -0,0 +1,172 @@
+package typx
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/xoctopus/x/syncx"
+	gopkg "golang.org/x/tools/go/packages"
+)
+
+// gDeclarations mapping loaded package to its declaration infos
+var gDeclarations = syncx.NewXmap[*gopkg.Package, *declarations]()
+
+// declarations holds declaration infos indexed by declared identifier's position
+type declarations struct {
+	comments map[token.Pos]*Comments
+	iotas    map[token.Pos]int
+}
+
+// Comments holds doc and line comments of a declaration
+type Comments struct {
+	Doc     *ast.CommentGroup
+	Comment *ast.CommentGroup
+}
+
+// Position returns the source position of obj. it returns zero position if the
+// package of obj was not loaded from source.
+func Position(obj types.Object) token.Position {
+	if p, ok := Syntax(obj.Pkg()); ok && p.Fset != nil {
+		return p.Fset.Position(obj.Pos())
+	}
+	return token.Position{}
+}
+
+// Doc returns the doc and line comments of obj.
+func Doc(obj types.Object) (doc string, comment string) {
+	if c := CommentsOf(obj); c != nil {
+		return text(c.Doc), text(c.Comment)
+	}
+	return "", ""
+}
+
+// CommentsOf returns comments of obj's declaration. it returns nil if obj has
+// no syntax or declaration comments.
+func CommentsOf(obj types.Object) *Comments {
+	if f, ok := obj.(*types.Func); ok {
+		obj = f.Origin()
+	}
+	if d := declarationsOf(obj); d != nil {
+		return d.comments[obj.Pos()]
+	}
+	return nil
+}
+
+// Iota returns the iota value of constant declaration. it returns false if c
+// has no syntax
+func Iota(c *types.Const) (int, bool) {
+	if d := declarationsOf(c); d != nil {
+		v, ok := d.iotas[c.Pos()]
+		return v, ok
+	}
+	return 0, false
+}
+
+func declarationsOf(obj types.Object) *declarations {
+	p, ok := Syntax(obj.Pkg())
+	if !ok {
+		return nil
+	}
+	d, ok := gDeclarations.Load(p)
+	if !ok {
+		d = index(p)
+		gDeclarations.Store(p, d)
+	}
+	return d
+}
+
+// fields collects comments of struct fields and interface methods in t
+func (d *declarations) fields(t ast.Expr) {
+	ast.Inspect(t, func(n ast.Node) bool {
+		f, ok := n.(*ast.Field)
+		if !ok || f.Doc == nil && f.Comment == nil {
+			return true
+		}
+		c := &Comments{Doc: f.Doc, Comment: f.Comment}
+		for _, name := range f.Names {
+			d.comments[name.Pos()] = c
+		}
+		if len(f.Names) == 0 {
+			if name := embedded(f.Type); name != nil {
+				d.comments[name.Pos()] = c
+			}
+		}
+		return true
+	})
+}
+
+// embedded returns the type name identifier of embedded field type, which is
+// the declared position of embedded field
+func embedded(t ast.Expr) *ast.Ident {
+	switch x := t.(type) {
+	case *ast.Ident:
+		return x
+	case *ast.StarExpr:
+		return embedded(x.X)
+	case *ast.SelectorExpr:
+		return x.Sel
+	case *ast.IndexExpr:
+		return embedded(x.X)
+	case *ast.IndexListExpr:
+		return embedded(x.X)
+	default:
+		return nil
+	}
+}
+
+func text(c *ast.CommentGroup) string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Text())
+}
+
+// index collects declaration infos by declared identifier's position
+func index(p *gopkg.Package) *declarations {
+	d := &declarations{
+		comments: make(map[token.Pos]*Comments),
+		iotas:    make(map[token.Pos]int),
+	}
+	for _, f := range p.Syntax {
+		for _, decl := range f.Decls {
+			switch x := decl.(type) {
+			case *ast.FuncDecl:
+				if x.Doc != nil {
+					d.comments[x.Name.Pos()] = &Comments{Doc: x.Doc}
+				}
+			case *ast.GenDecl:
+				for i, spec := range x.Specs {
+					doc := x.Doc
+					if len(x.Specs) > 1 || x.Lparen.IsValid() {
+						doc = nil
+					}
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						if s.Doc != nil {
+							doc = s.Doc
+						}
+						if doc != nil || s.Comment != nil {
+							d.comments[s.Name.Pos()] = &Comments{Doc: doc, Comment: s.Comment}
+						}
+						d.fields(s.Type)
+					case *ast.ValueSpec:
+						if s.Doc != nil {
+							doc = s.Doc
+						}
+						for _, name := range s.Names {
+							if doc != nil || s.Comment != nil {
+								d.comments[name.Pos()] = &Comments{Doc: doc, Comment: s.Comment}
+							}
+							if x.Tok == token.CONST {
+								d.iotas[name.Pos()] = i
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return d
+}
@@ -0,0 +1,47 @@
+package typx_test
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/internal/typx"
+)
+
+func TestDoc(t *testing.T) {
+	f := testPkg.Scope().Lookup("Apply")
+
+	pos := typx.Position(f)
+	Expect(t, strings.HasSuffix(pos.Filename, "function.go"), BeTrue())
+	Expect(t, pos.Line > 0, BeTrue())
+
+	doc, comment := typx.Doc(f)
+	Expect(t, doc, Equal("Apply calls f with each element of s and collects the results"))
+	Expect(t, comment, Equal(""))
+
+	// no syntax
+	v := types.NewVar(0, nil, "v", tInt)
+	Expect(t, typx.Position(v), Equal(token.Position{}))
+	Expect(t, typx.CommentsOf(v), BeNil[*typx.Comments]())
+
+	p, ok := typx.Syntax(testPkg)
+	Expect(t, ok, BeTrue())
+	Expect(t, p.Types, Equal(testPkg))
+}
+
+func TestIota(t *testing.T) {
+	c := testPkg.Scope().Lookup("STATUS_DONE").(*types.Const)
+	v, ok := typx.Iota(c)
+	Expect(t, ok, BeTrue())
+	Expect(t, v, Equal(3))
+
+	doc, comment := typx.Doc(c)
+	Expect(t, doc, Equal(""))
+	Expect(t, comment, Equal("done"))
+
+	_, ok = typx.Iota(types.NewConst(0, nil, "c", tInt, nil))
+	Expect(t, ok, BeFalse())
+}
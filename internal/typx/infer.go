@@ -0,0 +1,340 @@
+package typx
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// Infer completes the type arguments of tparams. targs holds the known type
+// arguments positionally and nil for the unknown ones. if sample is not nil, it
+// is unified with generic to infer type arguments from a concrete instance of
+// the generic shape, eg: unify `func(s S, f func(E) bool) bool` with
+// `func([]int, func(int) bool) bool` infers S=[]int and E=int. the remaining
+// arguments are inferred by core types of type parameter constraints, eg: S
+// constrained by `~[]E` infers E=int when S=[]int
+func Infer(tparams *types.TypeParamList, targs []types.Type, generic, sample types.Type) ([]types.Type, error) {
+	if len(targs) > tparams.Len() {
+		return nil, fmt.Errorf("got %d type arguments but %d type parameters", len(targs), tparams.Len())
+	}
+
+	u := &unifier{tparams: tparams, targs: make([]types.Type, tparams.Len())}
+	copy(u.targs, targs)
+
+	if sample != nil && !u.unify(generic, sample) {
+		return nil, fmt.Errorf("type %s does not match %s", sample, generic)
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i := range tparams.Len() {
+			core, tilde := CoreType(tparams.At(i))
+			if core == nil {
+				continue
+			}
+			if known := u.targs[i]; known != nil {
+				if tilde {
+					known = known.Underlying()
+				}
+				before := u.known()
+				if !u.unify(core, known) {
+					return nil, fmt.Errorf(
+						"%s (type %s) does not satisfy %s",
+						tparams.At(i), u.targs[i], tparams.At(i).Constraint(),
+					)
+				}
+				changed = changed || u.known() > before
+				continue
+			}
+			if u.bound(core) {
+				targ, err := u.subst(core)
+				if err != nil {
+					return nil, fmt.Errorf("cannot infer %s: %w", tparams.At(i), err)
+				}
+				u.targs[i] = targ
+				changed = true
+			}
+		}
+	}
+
+	for i, targ := range u.targs {
+		if targ == nil {
+			return nil, fmt.Errorf("cannot infer %s", tparams.At(i))
+		}
+	}
+	return u.targs, nil
+}
+
+// CoreType returns the single type term of type parameter's constraint and if
+// the term is a tilde term. it returns nil if constraint has no or multiple type
+// terms
+func CoreType(p *types.TypeParam) (types.Type, bool) {
+	iface, ok := p.Constraint().Underlying().(*types.Interface)
+	if !ok || iface.NumEmbeddeds() != 1 {
+		return nil, false
+	}
+	switch x := iface.EmbeddedType(0).(type) {
+	case *types.Union:
+		if x.Len() != 1 {
+			return nil, false
+		}
+		return x.Term(0).Type(), x.Term(0).Tilde()
+	default:
+		if _, ok := x.Underlying().(*types.Interface); ok {
+			return nil, false
+		}
+		return x, false
+	}
+}
+
+type unifier struct {
+	tparams *types.TypeParamList
+	targs   []types.Type
+}
+
+func (u *unifier) index(t types.Type) int {
+	if p, ok := t.(*types.TypeParam); ok {
+		for i := range u.tparams.Len() {
+			if u.tparams.At(i) == p {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (u *unifier) known() (n int) {
+	for _, targ := range u.targs {
+		if targ != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// bound reports if all type parameters referenced by t have type arguments.
+func (u *unifier) bound(t types.Type) bool {
+	switch x := types.Unalias(t).(type) {
+	case *types.TypeParam:
+		i := u.index(x)
+		return i < 0 || u.targs[i] != nil
+	case *types.Named:
+		for i := range x.TypeArgs().Len() {
+			if !u.bound(x.TypeArgs().At(i)) {
+				return false
+			}
+		}
+		return true
+	case *types.Pointer:
+		return u.bound(x.Elem())
+	case *types.Slice:
+		return u.bound(x.Elem())
+	case *types.Array:
+		return u.bound(x.Elem())
+	case *types.Chan:
+		return u.bound(x.Elem())
+	case *types.Map:
+		return u.bound(x.Key()) && u.bound(x.Elem())
+	case *types.Signature:
+		return u.bound(x.Params()) && u.bound(x.Results())
+	case *types.Tuple:
+		for i := range x.Len() {
+			if !u.bound(x.At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Struct:
+		for i := range x.NumFields() {
+			if !u.bound(x.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// subst substitutes the type parameters referenced by t with their type
+// arguments recursively, eg: `Wrap[[]E]` with E=int is `Wrap[[]int]`. the
+// instantiated named types are validated against their constraints.
+func (u *unifier) subst(t types.Type) (types.Type, error) {
+	var err error
+	switch x := types.Unalias(t).(type) {
+	case *types.TypeParam:
+		if i := u.index(x); i >= 0 {
+			return u.targs[i], nil
+		}
+		return x, nil
+	case *types.Named:
+		if x.TypeArgs().Len() == 0 {
+			return x, nil
+		}
+		targs := make([]types.Type, x.TypeArgs().Len())
+		for i := range targs {
+			if targs[i], err = u.subst(x.TypeArgs().At(i)); err != nil {
+				return nil, err
+			}
+		}
+		return types.Instantiate(nil, x.Origin(), targs, true)
+	case *types.Pointer:
+		elem, err := u.subst(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+	case *types.Slice:
+		elem, err := u.subst(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSlice(elem), nil
+	case *types.Array:
+		elem, err := u.subst(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.NewArray(elem, x.Len()), nil
+	case *types.Chan:
+		elem, err := u.subst(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.NewChan(x.Dir(), elem), nil
+	case *types.Map:
+		key, err := u.subst(x.Key())
+		if err != nil {
+			return nil, err
+		}
+		elem, err := u.subst(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMap(key, elem), nil
+	case *types.Signature:
+		params, err := u.subst(x.Params())
+		if err != nil {
+			return nil, err
+		}
+		results, err := u.subst(x.Results())
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSignatureType(nil, nil, nil, params.(*types.Tuple), results.(*types.Tuple), x.Variadic()), nil
+	case *types.Tuple:
+		if x == nil {
+			return x, nil
+		}
+		vars := make([]*types.Var, x.Len())
+		for i := range vars {
+			v := x.At(i)
+			typ, err := u.subst(v.Type())
+			if err != nil {
+				return nil, err
+			}
+			vars[i] = types.NewParam(v.Pos(), v.Pkg(), v.Name(), typ)
+		}
+		return types.NewTuple(vars...), nil
+	case *types.Struct:
+		fields := make([]*types.Var, x.NumFields())
+		tags := make([]string, x.NumFields())
+		for i := range fields {
+			f := x.Field(i)
+			typ, err := u.subst(f.Type())
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = types.NewField(f.Pos(), f.Pkg(), f.Name(), typ, f.Anonymous())
+			tags[i] = x.Tag(i)
+		}
+		return types.NewStruct(fields, tags), nil
+	default:
+		return x, nil
+	}
+}
+
+// unify unifies generic type x with concrete type y and records the type
+// arguments of type parameters x referenced
+func (u *unifier) unify(x, y types.Type) bool {
+	x, y = types.Unalias(x), types.Unalias(y)
+
+	if i := u.index(x); i >= 0 {
+		if u.targs[i] == nil {
+			u.targs[i] = y
+			return true
+		}
+		return types.Identical(u.targs[i], y)
+	}
+
+	if nx, ok := x.(*types.Named); ok {
+		ny, ok := y.(*types.Named)
+		if !ok || nx.Origin().Obj() != ny.Origin().Obj() {
+			return false
+		}
+		if nx.TypeArgs().Len() != ny.TypeArgs().Len() {
+			return false
+		}
+		for i := range nx.TypeArgs().Len() {
+			if !u.unify(nx.TypeArgs().At(i), ny.TypeArgs().At(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// x is a type literal, so a named y matches if its underlying does
+	if _, ok := y.(*types.Named); ok {
+		y = y.Underlying()
+	}
+
+	switch tx := x.(type) {
+	case *types.Pointer:
+		ty, ok := y.(*types.Pointer)
+		return ok && u.unify(tx.Elem(), ty.Elem())
+	case *types.Slice:
+		ty, ok := y.(*types.Slice)
+		return ok && u.unify(tx.Elem(), ty.Elem())
+	case *types.Array:
+		ty, ok := y.(*types.Array)
+		return ok && tx.Len() == ty.Len() && u.unify(tx.Elem(), ty.Elem())
+	case *types.Chan:
+		ty, ok := y.(*types.Chan)
+		return ok && tx.Dir() == ty.Dir() && u.unify(tx.Elem(), ty.Elem())
+	case *types.Map:
+		ty, ok := y.(*types.Map)
+		return ok && u.unify(tx.Key(), ty.Key()) && u.unify(tx.Elem(), ty.Elem())
+	case *types.Signature:
+		ty, ok := y.(*types.Signature)
+		return ok && tx.Variadic() == ty.Variadic() &&
+			u.unify(tx.Params(), ty.Params()) &&
+			u.unify(tx.Results(), ty.Results())
+	case *types.Tuple:
+		ty, ok := y.(*types.Tuple)
+		if !ok || tx.Len() != ty.Len() {
+			return false
+		}
+		for i := range tx.Len() {
+			if !u.unify(tx.At(i).Type(), ty.At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Struct:
+		ty, ok := y.(*types.Struct)
+		if !ok || tx.NumFields() != ty.NumFields() {
+			return false
+		}
+		for i := range tx.NumFields() {
+			fx, fy := tx.Field(i), ty.Field(i)
+			if fx.Name() != fy.Name() || fx.Anonymous() != fy.Anonymous() || tx.Tag(i) != ty.Tag(i) {
+				return false
+			}
+			if !u.unify(fx.Type(), fy.Type()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return types.Identical(x, y)
+	}
+}
@@ -0,0 +1,93 @@
+package typx_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/internal/typx"
+)
+
+func TestInfer(t *testing.T) {
+	g := typx.Lookup[*types.Named](testPkg, "ContainsFunc")
+	tparams := g.TypeParams()
+
+	t.Run("CoreType", func(t *testing.T) {
+		core, tilde := typx.CoreType(tparams.At(0))
+		Expect(t, core.String(), Equal("[]E"))
+		Expect(t, tilde, BeTrue())
+
+		core, _ = typx.CoreType(tparams.At(1))
+		Expect(t, core, BeNil[types.Type]())
+	})
+
+	t.Run("ByCoreType", func(t *testing.T) {
+		targs, err := typx.Infer(tparams, []types.Type{types.NewSlice(tInt)}, nil, nil)
+		Expect(t, err, BeNil[error]())
+		Expect(t, types.Identical(targs[1], tInt), BeTrue())
+
+		targs, err = typx.Infer(tparams, []types.Type{nil, tString}, nil, nil)
+		Expect(t, err, BeNil[error]())
+		Expect(t, types.Identical(targs[0], tStringSlice), BeTrue())
+	})
+
+	t.Run("BySample", func(t *testing.T) {
+		sample := types.NewSignatureType(
+			nil, nil, nil,
+			types.NewTuple(
+				types.NewParam(0, nil, "", tStringSlice),
+				types.NewParam(0, nil, "", types.NewSignatureType(
+					nil, nil, nil,
+					types.NewTuple(types.NewParam(0, nil, "", tString)),
+					types.NewTuple(types.NewParam(0, nil, "", tBool)),
+					false,
+				)),
+			),
+			types.NewTuple(types.NewParam(0, nil, "", tBool)),
+			false,
+		)
+		targs, err := typx.Infer(tparams, nil, g.Underlying(), sample)
+		Expect(t, err, BeNil[error]())
+		Expect(t, types.Identical(targs[0], tStringSlice), BeTrue())
+		Expect(t, types.Identical(targs[1], tString), BeTrue())
+
+		_, err = typx.Infer(tparams, nil, g.Underlying(), tString)
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		_, err := typx.Infer(tparams, []types.Type{tInt, tInt, tInt}, nil, nil)
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.Infer(tparams, []types.Type{tInt}, nil, nil)
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.Infer(tparams, nil, nil, nil)
+		Expect(t, err, NotBeNil[error]())
+	})
+	t.Run("NestedCoreType", func(t *testing.T) {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "p.go", `package p
+
+type Wrap[T comparable] struct{ V T }
+
+type Nested[W Wrap[*E], E any] struct{}
+
+type Strict[W Wrap[E], E any] struct{}
+`, 0)
+		Expect(t, err, BeNil[error]())
+		// Strict violates the constraint of Wrap, it is checked by Infer
+		pkg, _ := (&types.Config{Error: func(error) {}}).Check("p", fset, []*ast.File{f}, nil)
+
+		nested := pkg.Scope().Lookup("Nested").Type().(*types.Named)
+		targs, err := typx.Infer(nested.TypeParams(), []types.Type{nil, tInt}, nil, nil)
+		Expect(t, err, BeNil[error]())
+		Expect(t, targs[0].String(), Equal("p.Wrap[*int]"))
+
+		strict := pkg.Scope().Lookup("Strict").Type().(*types.Named)
+		_, err = typx.Infer(strict.TypeParams(), []types.Type{nil, tStringSlice}, nil, nil)
+		Expect(t, err, NotBeNil[error]())
+	})
+}
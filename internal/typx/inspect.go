@@ -38,6 +38,8 @@ func (w *Walker) Visit(t types.Type) {
 type Field struct {
 	f   *types.Var
 	tag string
+	s   *types.Struct
+	idx int
 }
 
 func (f *Field) Var() *types.Var {
@@ -48,6 +50,16 @@ func (f *Field) Tag() string {
 	return f.tag
 }
 
+// Struct returns the struct type which declares this field
+func (f *Field) Struct() *types.Struct {
+	return f.s
+}
+
+// Index returns the field index in its declaring struct
+func (f *Field) Index() int {
+	return f.idx
+}
+
 type Fields map[string][]*Field
 
 type Method struct {
@@ -91,8 +103,9 @@ type inspector struct {
 	walker  Walker
 }
 
-func (i *inspector) appendField(v *types.Var, tag string) {
-	i.fields[v.Name()] = append(i.fields[v.Name()], &Field{v, tag})
+func (i *inspector) appendField(s *types.Struct, idx int) {
+	v := s.Field(idx)
+	i.fields[v.Name()] = append(i.fields[v.Name()], &Field{v, s.Tag(idx), s, idx})
 }
 
 func (i *inspector) appendMethod(f *types.Func) {
@@ -143,7 +156,7 @@ func (i *inspector) inspect(t types.Type) {
 	case *types.Struct:
 		for idx := range x.NumFields() {
 			f := x.Field(idx)
-			i.appendField(f, x.Tag(idx))
+			i.appendField(x, idx)
 			if f.Anonymous() {
 				i.inspect(f.Type())
 			}
@@ -214,7 +227,7 @@ func InspectField(t types.Type, match func(string) bool, w Walker, entries int)
 				if directed != nil {
 					return nil
 				}
-				directed = &Field{f, x.Tag(i)}
+				directed = &Field{f, x.Tag(i), x, i}
 			}
 			if f.Anonymous() {
 				if field := InspectField(f.Type(), match, w, entries+1); field != nil {
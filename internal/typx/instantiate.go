@@ -76,7 +76,7 @@ func Instantiate(t types.Type, args ...types.Type) types.Type {
 			must.BeTrue(n.TypeParams().Len() == len(args))
 			targs = args
 		}
-		tt, err := types.Instantiate(nil, x, targs, true)
+		tt, err := types.Instantiate(nil, n.Origin(), targs, true)
 		must.NoErrorF(err, "failed to instantiate.")
 		return tt
 	}
@@ -89,7 +89,7 @@ func Underlying(t types.Type) types.Type {
 		TypeArgs() *types.TypeList
 	})
 
-	if !ok || tt.TypeParams().Len() == 0 {
+	if !ok || tt.TypeParams().Len() == 0 || tt.TypeArgs().Len() == 0 {
 		return t.Underlying()
 	}
 
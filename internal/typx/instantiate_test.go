@@ -68,4 +68,15 @@ func TestInstantiate(t *testing.T) {
 
 		Expect(t, instantiated.String(), Equal(underlying.String()))
 	})
+
+	t.Run("InstantiatedTypeArgs", func(t *testing.T) {
+		named := typx.NewTTByRT(
+			reflect.TypeFor[testdata.TypedMap[testdata.Serialized[string], int]](),
+		)
+		key := typx.Underlying(named).(*types.Map).Key().(*types.Named)
+		arg := named.(*types.Named).TypeArgs().At(0).(*types.Named)
+
+		Expect(t, key.Origin(), Equal(arg.Origin()))
+		Expect(t, key.NumMethods(), Equal(arg.NumMethods()))
+	})
 }
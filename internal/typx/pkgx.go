@@ -2,6 +2,7 @@ package typx
 
 import (
 	"errors"
+	"fmt"
 	"go/types"
 	"reflect"
 	"slices"
@@ -12,28 +13,39 @@ import (
 	gopkg "golang.org/x/tools/go/packages"
 )
 
-var gPackages = syncx.NewXmap[string, *types.Package]()
+var (
+	// gPackages mapping package path to loaded package
+	gPackages = syncx.NewXmap[string, *gopkg.Package]()
+	// gSyntaxes mapping types.Package to loaded package with syntax and file
+	// set, includes all dependencies of loaded packages
+	gSyntaxes = syncx.NewXmap[*types.Package, *gopkg.Package]()
+)
+
+func Load(path string) *types.Package {
+	p, err := LoadPackage(path)
+	must.NoError(err)
+	return p.Types
+}
 
-func Load(path string) (p *types.Package) {
+// LoadPackage loads package by path with syntax and type info retained.
+func LoadPackage(path string) (p *gopkg.Package, err error) {
 	if x, ok := gPackages.Load(path); ok {
-		return x
+		return x, nil
 	}
 
-	defer func() {
-		if p != nil {
-			gPackages.Store(p.Path(), p)
-		}
-	}()
-
 	_path := path
 	if strings.HasSuffix(path, "_test") {
 		path = strings.TrimSuffix(_path, "_test")
 	}
 
 	pkgs, err := gopkg.Load(&gopkg.Config{Mode: 9183, Tests: true}, path)
-	must.NoErrorF(err, "failed to load %s", path)
-	must.BeTrueF(len(pkgs) > 0, "no packages loaded")
-	must.NoErrorF(errors.Join(
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("failed to load %s: no packages loaded", path)
+	}
+	if err = errors.Join(
 		slices.Collect(func(yield func(error) bool) {
 			for _, pkg := range pkgs {
 				for _, x := range pkg.Errors {
@@ -41,16 +53,37 @@ func Load(path string) (p *types.Package) {
 				}
 			}
 		})...,
-	), "failed to load %s", path)
+	); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
 
 	for i := range pkgs {
 		if pkgs[i].PkgPath == _path {
-			p = pkgs[i].Types
+			p = pkgs[i]
 			break
 		}
 	}
-	must.BeTrueF(p != nil, "failed to load %s", path)
-	return p
+	if p == nil {
+		return nil, fmt.Errorf("failed to load %s", path)
+	}
+
+	gopkg.Visit(pkgs, nil, func(x *gopkg.Package) {
+		if x.Types != nil {
+			if _, ok := gSyntaxes.Load(x.Types); !ok {
+				gSyntaxes.Store(x.Types, x)
+			}
+		}
+	})
+	gPackages.Store(p.Types.Path(), p)
+	return p, nil
+}
+
+// Syntax returns the loaded package of p, which retains syntax and file set.
+func Syntax(p *types.Package) (*gopkg.Package, bool) {
+	if p == nil {
+		return nil, false
+	}
+	return gSyntaxes.Load(p)
 }
 
 func Lookup[T types.Type](p *types.Package, name string) T {
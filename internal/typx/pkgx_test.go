@@ -1,6 +1,7 @@
 package typx_test
 
 import (
+	"go/token"
 	"testing"
 
 	. "github.com/xoctopus/x/testx"
@@ -16,3 +17,13 @@ func TestPackageLoad(t *testing.T) {
 		pkg = typx.Load("github.com/xoctopus/typx/pkg/typex")
 	})
 }
+
+func TestLoadPackage(t *testing.T) {
+	p, err := typx.LoadPackage("github.com/xoctopus/typx/testdata")
+	Expect(t, err, BeNil[error]())
+	Expect(t, len(p.Syntax) > 0, BeTrue())
+	Expect(t, p.Fset, NotBeNil[*token.FileSet]())
+
+	_, err = typx.LoadPackage("github.com/xoctopus/typx/pkg/typex")
+	Expect(t, err, NotBeNil[error]())
+}
@@ -426,6 +426,8 @@ func wrapTT(t types.Type) (id string) {
 		return b.String()
 	case *types.Slice:
 		return fmt.Sprintf("[]%s", wrapTT(x.Elem()))
+	case *types.TypeParam:
+		return x.Obj().Name()
 	case *types.Struct:
 		if x.NumFields() == 0 {
 			return "struct {}"
@@ -452,8 +454,6 @@ func wrapTT(t types.Type) (id string) {
 	default:
 		n, ok := t.(*types.Named)
 		must.BeTrueF(ok, "invalid wrapTT type: %T", x)
-		ok = n.TypeArgs().Len() == n.TypeParams().Len()
-		must.BeTrueF(ok, "uninstantiated generic type: %s", x.String())
 
 		b := strings.Builder{}
 		path := ""
@@ -475,6 +475,16 @@ func wrapTT(t types.Type) (id string) {
 				b.WriteString(wrapTT(targ))
 			}
 			b.WriteString("]")
+		} else if n.TypeParams().Len() > 0 {
+			// uninstantiated generic type, use type parameter names as arguments
+			b.WriteString("[")
+			for i := range n.TypeParams().Len() {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				b.WriteString(n.TypeParams().At(i).Obj().Name())
+			}
+			b.WriteString("]")
 		}
 		return wrapID(b.String())
 	}
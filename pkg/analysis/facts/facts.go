@@ -0,0 +1,123 @@
+// Package facts provides an analyzer which computes typx information of named
+// types and exports them as facts, so that analyzers depending on it can
+// consume typx information across packages without loading packages again.
+package facts
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:       "typxfacts",
+	Doc:        "computes typx facts of package level named types",
+	URL:        "https://pkg.go.dev/github.com/xoctopus/typx/pkg/analysis/facts",
+	Run:        run,
+	FactTypes:  []analysis.Fact{new(TypeFact)},
+	ResultType: reflect.TypeFor[*Result](),
+}
+
+// TypeFact is the typx information of a package level named type. generic
+// types are not computed until instantiated, so they have no facts.
+type TypeFact struct {
+	// ID is the wrapped type id
+	ID string
+	// Fingerprint is the structural fingerprint, see typx.Fingerprint
+	Fingerprint [32]byte
+	// Methods is the method set of T
+	Methods []*MethodFact
+	// PtrMethods is the method set of *T, it is empty if T is an interface
+	PtrMethods []*MethodFact
+}
+
+func (*TypeFact) AFact() {}
+
+func (f *TypeFact) String() string {
+	names := func(ms []*MethodFact) string {
+		s := make([]string, len(ms))
+		for i, m := range ms {
+			s[i] = m.Name
+		}
+		return strings.Join(s, ",")
+	}
+	return fmt.Sprintf("%s#%x methods(%s) ptrMethods(%s)", f.ID, f.Fingerprint[:4], names(f.Methods), names(f.PtrMethods))
+}
+
+// MethodFact is a method in method set
+type MethodFact struct {
+	Name string
+	// Type is the literal of method type with receiver as its first parameter
+	// except interface methods, like reflect.Method
+	Type string
+}
+
+// Result holds facts of analyzed package and its dependencies
+type Result struct {
+	facts map[types.Object]*TypeFact
+}
+
+// Fact returns the fact of declared named type t
+func (r *Result) Fact(t types.Type) (*TypeFact, bool) {
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok || n.Origin() != n {
+		return nil, false
+	}
+	f, ok := r.facts[n.Obj()]
+	return f, ok
+}
+
+// Facts returns all the facts keyed by type name objects
+func (r *Result) Facts() map[types.Object]*TypeFact {
+	return r.facts
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		n, ok := obj.Type().(*types.Named)
+		if !ok || n.TypeParams().Len() > 0 {
+			continue
+		}
+		pass.ExportObjectFact(obj, NewTypeFact(n))
+	}
+
+	r := &Result{facts: make(map[types.Object]*TypeFact)}
+	for _, f := range pass.AllObjectFacts() {
+		r.facts[f.Object] = f.Fact.(*TypeFact)
+	}
+	return r, nil
+}
+
+// NewTypeFact computes fact of non-generic named type n
+func NewTypeFact(n *types.Named) *TypeFact {
+	t := typx.NewTType(n)
+	f := &TypeFact{
+		ID:          typi.Wrap(n),
+		Fingerprint: typx.Fingerprint(t),
+		Methods:     methods(t),
+	}
+	if t.Kind() != reflect.Interface {
+		f.PtrMethods = methods(typx.NewTType(types.NewPointer(n)))
+	}
+	return f
+}
+
+func methods(t typx.Type) []*MethodFact {
+	ms := make([]*MethodFact, 0, t.NumMethod())
+	for i := range t.NumMethod() {
+		m := t.Method(i)
+		ms = append(ms, &MethodFact{Name: m.Name(), Type: m.Type().String()})
+	}
+	return ms
+}
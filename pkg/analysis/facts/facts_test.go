@@ -0,0 +1,72 @@
+package facts_test
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/xoctopus/typx/pkg/analysis/facts"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+const dir = "../../../testdata/analysis/facts"
+
+// consumer reports facts of named struct field types
+var consumer = &analysis.Analyzer{
+	Name:     "consumer",
+	Doc:      "reports facts of struct field types",
+	Requires: []*analysis.Analyzer{facts.Analyzer},
+	Run: func(pass *analysis.Pass) (any, error) {
+		r := pass.ResultOf[facts.Analyzer].(*facts.Result)
+		for _, f := range pass.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				if s, ok := n.(*ast.StructType); ok {
+					for _, field := range s.Fields.List {
+						t := pass.TypesInfo.TypeOf(field.Type)
+						if p, ok := t.(*types.Pointer); ok {
+							t = p.Elem()
+						}
+						if fact, ok := r.Fact(t); ok {
+							name := t.(*types.Named).Obj().Name()
+							if len(field.Names) > 0 {
+								name = field.Names[0].Name
+							}
+							pass.Reportf(field.Pos(), "%s: %s", name, fact)
+						}
+					}
+				}
+				return true
+			})
+		}
+		return nil, nil
+	},
+}
+
+func TestAnalyzer(t *testing.T) {
+	dir, err := filepath.Abs(dir)
+	Expect(t, err, BeNil[error]())
+
+	analysistest.Run(t, dir, facts.Analyzer, "a", "b")
+	analysistest.Run(t, dir, consumer, "c")
+}
+
+func TestNewTypeFact(t *testing.T) {
+	p, err := typx.Load("github.com/xoctopus/typx/testdata")
+	Expect(t, err, BeNil[error]())
+
+	x, _ := p.Type("Circle")
+	f := facts.NewTypeFact(x.Unwrap().(*types.Named))
+	Expect(t, f.ID, Equal("github_com_xoctopus_typx_testdata.Circle"))
+	Expect(t, f.Fingerprint, Equal(typx.Fingerprint(typx.NewRType(reflect.TypeFor[testdata.Circle]()))))
+	Expect(t, len(f.Methods), Equal(1))
+	Expect(t, f.Methods[0].Name, Equal("Area"))
+	Expect(t, f.Methods[0].Type, Equal("func(github.com/xoctopus/typx/testdata.Circle) float64"))
+	Expect(t, len(f.PtrMethods), Equal(1))
+}
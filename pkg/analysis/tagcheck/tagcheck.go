@@ -0,0 +1,151 @@
+// Package tagcheck provides an analyzer which reports mistakes of struct tags
+// of the well-known keys: json, yaml, xml and db.
+package tagcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/xoctopus/typx/internal/jsonfields"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "tagcheck",
+	Doc: `reports mistakes of json, yaml, xml and db struct tags
+
+It reports:
+  - tags on unexported fields, which are ignored by encoders
+  - unknown options of tag keys
+  - omitempty of json and xml on struct typed fields, which are never empty
+  - duplicated json names at the same depth after embedded fields promoted,
+    both fields are ignored by encoding/json
+
+structs declared in generic types and functions are not checked.`,
+	URL: "https://pkg.go.dev/github.com/xoctopus/typx/pkg/analysis/tagcheck",
+	Run: run,
+}
+
+// options are the known options of tag keys
+var options = map[string][]string{
+	"json": {"omitempty", "omitzero", "string"},
+	"yaml": {"omitempty", "flow", "inline"},
+	"xml":  {"attr", "chardata", "cdata", "innerxml", "comment", "any", "omitempty"},
+	"db":   {},
+}
+
+var keys = []string{"json", "yaml", "xml", "db"}
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.TypeSpec:
+				return x.TypeParams == nil
+			case *ast.FuncDecl:
+				return x.Type.TypeParams == nil && !generic(x.Recv)
+			case *ast.StructType:
+				if s, ok := pass.TypesInfo.TypeOf(x).(*types.Struct); ok {
+					check(pass, x, typx.NewTType(s))
+				}
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// generic reports if method receiver is a generic type
+func generic(recv *ast.FieldList) bool {
+	if recv == nil || len(recv.List) == 0 {
+		return false
+	}
+	x := recv.List[0].Type
+	if star, ok := x.(*ast.StarExpr); ok {
+		x = star.X
+	}
+	switch x.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+func check(pass *analysis.Pass, s *ast.StructType, t typx.Type) {
+	// positions of fields by index
+	positions := make([]token.Pos, 0, t.NumField())
+	for _, f := range s.Fields.List {
+		n := max(len(f.Names), 1)
+		for i := range n {
+			pos := f.Pos()
+			if len(f.Names) > 0 {
+				pos = f.Names[i].Pos()
+			}
+			positions = append(positions, pos)
+		}
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		for _, key := range keys {
+			value, ok := f.Tag().Lookup(key)
+			if !ok || value == "-" {
+				continue
+			}
+			if f.PkgPath() != "" && !f.Anonymous() {
+				pass.Reportf(positions[i], "struct field %s has %s tag but is not exported", f.Name(), key)
+				continue
+			}
+			_, opts, ok := strings.Cut(value, ",")
+			if !ok {
+				continue
+			}
+			for _, opt := range strings.Split(opts, ",") {
+				// empty options are ignored by encoders, eg: `json:"name,"`
+				if opt == "" {
+					continue
+				}
+				if !slices.Contains(options[key], opt) {
+					pass.Reportf(positions[i], "unknown option %q in %s tag of struct field %s", opt, key, f.Name())
+				}
+				if opt == "omitempty" && (key == "json" || key == "xml") && f.Type().Kind() == reflect.Struct {
+					msg := "omitempty in %s tag has no effect on struct field %s of struct type %s"
+					if key == "json" {
+						msg += ", use omitzero instead"
+					}
+					pass.Reportf(positions[i], msg, key, f.Name(), f.Type())
+				}
+			}
+		}
+	}
+
+	groups := make(map[string][]jsonfields.Field[typx.Type])
+	for _, f := range jsonfields.All(t) {
+		groups[f.Name] = append(groups[f.Name], f)
+	}
+	for _, name := range slices.Sorted(maps.Keys(groups)) {
+		// fields are in breadth-first order, the shallowest are dominant
+		depth := groups[name][0].Depth()
+		dominants := slices.DeleteFunc(groups[name], func(f jsonfields.Field[typx.Type]) bool { return f.Depth() > depth })
+		tagged := slices.DeleteFunc(slices.Clone(dominants), func(f jsonfields.Field[typx.Type]) bool { return !f.Tagged })
+		if len(dominants) == 1 || len(tagged) == 1 {
+			continue
+		}
+		conflicts := dominants
+		if len(tagged) > 1 {
+			conflicts = tagged
+		}
+		pass.Reportf(
+			positions[conflicts[1].Index[0]],
+			"json name %q of struct field %s conflicts with %s, both are ignored by encoding/json",
+			name, conflicts[1].Path, conflicts[0].Path,
+		)
+	}
+}
@@ -0,0 +1,18 @@
+package tagcheck_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/xoctopus/typx/pkg/analysis/tagcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	dir, err := filepath.Abs("../../../testdata/analysis/tagcheck")
+	Expect(t, err, BeNil[error]())
+
+	analysistest.Run(t, dir, tagcheck.Analyzer, "a")
+}
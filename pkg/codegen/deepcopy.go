@@ -0,0 +1,629 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// GenerateDeepCopy generates `DeepCopy`, `DeepCopyInto` and `Equal` methods for
+// named struct types ts declared in the local package of *Imports carried by
+// ctx. reflect types are converted to static types.
+//
+// values are copied by assignment and compared by `==` if their types are
+// comparable and contain no pointers or interfaces. pointers, slices, maps and
+// arrays are copied and compared element by element. types in ts call each
+// other's generated methods, and recursive types such as `BTreeNode[T]` track
+// visited pointers to handle reference cycles. types declared in other
+// packages are copied by their `DeepCopy` methods and compared by their `Equal`
+// methods if exist, otherwise they are treated as opaque values. interface and
+// function values are copied by assignment, so the dynamic values of interfaces
+// are shared between the copies, which is noted in generated code. fields of
+// generic types referencing type parameters are compared by reflect.DeepEqual,
+// their pointers, slices and maps are allocated and the values of type
+// parameters are copied by assignment.
+func GenerateDeepCopy(ctx context.Context, ts ...typx.Type) (string, error) {
+	namer, _ := typx.CtxPkgNamer.From(ctx)
+	imports, ok := namer.(*Imports)
+	if !ok {
+		return "", fmt.Errorf("expect *Imports as package namer in context")
+	}
+
+	g := &generator{ctx: ctx, imports: imports, targets: make(map[*types.TypeName]*target)}
+	named := make([]*types.Named, len(ts))
+	for i, t := range ts {
+		if r, ok := t.Unwrap().(reflect.Type); ok {
+			x, err := typi.TryNewTTByRT(r)
+			if err != nil {
+				return "", err
+			}
+			t = typx.NewTTypeContext(ctx, x)
+		}
+		n, ok := t.Unwrap().(*types.Named)
+		if !ok || t.Kind() != reflect.Struct {
+			return "", fmt.Errorf("%s is not a named struct type", t)
+		}
+		if t.PkgPath() != imports.Local() {
+			return "", fmt.Errorf("%s is not declared in package %s", t, imports.Local())
+		}
+		if n.TypeArgs().Len() > 0 {
+			return "", fmt.Errorf("%s is instantiated, use its generic type instead", t)
+		}
+		g.targets[n.Obj()] = &target{t: t, n: n}
+		named[i] = n
+	}
+	for _, x := range g.targets {
+		x.recursive = g.reaches(x.n, x.n.Underlying(), make(map[types.Type]bool))
+	}
+
+	for _, n := range named {
+		if err := g.generate(g.targets[n.Obj()]); err != nil {
+			return "", err
+		}
+	}
+
+	code, err := format.Source([]byte("package x\n" + g.b.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return strings.TrimPrefix(string(code), "package x\n\n"), nil
+}
+
+type target struct {
+	t typx.Type
+	n *types.Named
+	// recursive target references itself, it tracks visited pointers
+	recursive bool
+}
+
+type generator struct {
+	ctx     context.Context
+	imports *Imports
+	targets map[*types.TypeName]*target
+	b       strings.Builder
+	// recursive reports if generating methods of recursive target
+	recursive bool
+	// inlining named types which are not targets
+	inlining []string
+}
+
+func (g *generator) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&g.b, format, args...)
+}
+
+func (g *generator) lit(t typx.Type) string {
+	return typx.TypeLit(g.ctx, t.Unwrap())
+}
+
+// target returns generated target of t
+func (g *generator) target(t typx.Type) *target {
+	if n, ok := t.Unwrap().(*types.Named); ok {
+		return g.targets[n.Obj()]
+	}
+	return nil
+}
+
+// reaches reports if t references target n
+func (g *generator) reaches(n *types.Named, t types.Type, visited map[types.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch x := types.Unalias(t).(type) {
+	case *types.Named:
+		if x.Obj() == n.Obj() {
+			return true
+		}
+		for i := range x.TypeArgs().Len() {
+			if g.reaches(n, x.TypeArgs().At(i), visited) {
+				return true
+			}
+		}
+		if pkg := x.Obj().Pkg(); pkg != nil && pkg.Path() == g.imports.Local() {
+			return g.reaches(n, x.Underlying(), visited)
+		}
+	case *types.Pointer:
+		return g.reaches(n, x.Elem(), visited)
+	case *types.Slice:
+		return g.reaches(n, x.Elem(), visited)
+	case *types.Array:
+		return g.reaches(n, x.Elem(), visited)
+	case *types.Map:
+		return g.reaches(n, x.Key(), visited) || g.reaches(n, x.Elem(), visited)
+	case *types.Struct:
+		for i := range x.NumFields() {
+			if g.reaches(n, x.Field(i).Type(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *generator) generate(x *target) error {
+	recv := g.lit(x.t)
+	g.recursive = x.recursive
+
+	g.printf("\n// DeepCopy returns a deep copy of in\n")
+	g.printf("func (in *%s) DeepCopy() *%s {\n", recv, recv)
+	if x.recursive {
+		g.printf("return in.deepCopy(make(map[any]any))\n}\n")
+		g.printf("\nfunc (in *%s) deepCopy(seen map[any]any) *%s {\n", recv, recv)
+		g.printf("if in == nil {\nreturn nil\n}\n")
+		g.printf("if x, ok := seen[in]; ok {\nreturn x.(*%s)\n}\n", recv)
+		g.printf("out := new(%s)\nseen[in] = out\nin.deepCopyInto(out, seen)\nreturn out\n}\n", recv)
+	} else {
+		g.printf("if in == nil {\nreturn nil\n}\n")
+		g.printf("out := new(%s)\nin.DeepCopyInto(out)\nreturn out\n}\n", recv)
+	}
+
+	g.printf("\n// DeepCopyInto copies in into out deeply\n")
+	g.printf("func (in *%s) DeepCopyInto(out *%s) {\n", recv, recv)
+	if x.recursive {
+		g.printf("in.deepCopyInto(out, map[any]any{in: out})\n}\n")
+		g.printf("\nfunc (in *%s) deepCopyInto(out *%s, seen map[any]any) {\n", recv, recv)
+	}
+	g.printf("*out = *in\n")
+	if err := g.copyStruct(x.t); err != nil {
+		return err
+	}
+	g.printf("}\n")
+
+	g.printf("\n// Equal reports whether in and x are deeply equal\n")
+	g.printf("func (in *%s) Equal(x *%s) bool {\n", recv, recv)
+	if x.recursive {
+		g.printf("return in.equal(x, make(map[[2]any]bool))\n}\n")
+		g.printf("\nfunc (in *%s) equal(x *%s, seen map[[2]any]bool) bool {\n", recv, recv)
+	}
+	g.printf("if in == x {\nreturn true\n}\n")
+	g.printf("if in == nil || x == nil {\nreturn false\n}\n")
+	if x.recursive {
+		g.printf("if seen[[2]any{in, x}] {\nreturn true\n}\n")
+		g.printf("seen[[2]any{in, x}] = true\n")
+	}
+	if g.comparable(x.t) {
+		g.printf("return *in == *x\n}\n")
+		return nil
+	}
+	g.printf("a, b := in, x\n")
+	if err := g.equalStruct(x.t); err != nil {
+		return err
+	}
+	g.printf("return true\n}\n")
+	return nil
+}
+
+// fields returns the struct fields of t and the static types of fields which
+// reference type parameters, the others are nil
+func fields(t typx.Type) ([]typx.StructField, []types.Type) {
+	var s *types.Struct
+	if x, ok := t.Unwrap().(types.Type); ok {
+		s, _ = x.Underlying().(*types.Struct)
+	}
+
+	fs := make([]typx.StructField, t.NumField())
+	params := make([]types.Type, t.NumField())
+	for i := range fs {
+		fs[i] = t.Field(i)
+		if s != nil && typeParams(s.Field(i).Type()) {
+			params[i] = s.Field(i).Type()
+		}
+	}
+	return fs, params
+}
+
+// typeParams reports if t references type parameters. the type arguments of
+// named types are not included, their fields are checked when accessing.
+func typeParams(t types.Type) bool {
+	switch x := types.Unalias(t).(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return typeParams(x.Elem())
+	case *types.Slice:
+		return typeParams(x.Elem())
+	case *types.Array:
+		return typeParams(x.Elem())
+	case *types.Chan:
+		return typeParams(x.Elem())
+	case *types.Map:
+		return typeParams(x.Key()) || typeParams(x.Elem())
+	case *types.Struct:
+		for i := range x.NumFields() {
+			if typeParams(x.Field(i).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mutable reports if values of t referencing type parameters share memory
+// after assignment. named types are copied by assignment.
+func mutable(t types.Type) bool {
+	switch x := types.Unalias(t).(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	case *types.Array:
+		return mutable(x.Elem())
+	case *types.Struct:
+		for i := range x.NumFields() {
+			if mutable(x.Field(i).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyParams emits statements copying *in to *out, where in and out are
+// pointers of t which references type parameters and *out was assigned by
+// *in. the containers are allocated and the values of type parameters are
+// copied by assignment.
+func (g *generator) copyParams(t types.Type) {
+	lit := func(t types.Type) string {
+		return types.TypeString(t, func(p *types.Package) string {
+			return g.imports.PackageName(p.Path())
+		})
+	}
+
+	switch x := types.Unalias(t).(type) {
+	case *types.Pointer:
+		g.printf("if *in != nil {\n*out = new(%s)\n**out = **in\n", lit(x.Elem()))
+		if mutable(x.Elem()) {
+			g.printf("in, out := *in, *out\n")
+			g.copyParams(x.Elem())
+		}
+		g.printf("}\n")
+	case *types.Slice:
+		g.printf("if *in != nil {\n*out = make(%s, len(*in))\ncopy(*out, *in)\n", lit(x))
+		if mutable(x.Elem()) {
+			g.printf("for i := range *in {\nin, out := &(*in)[i], &(*out)[i]\n")
+			g.copyParams(x.Elem())
+			g.printf("}\n")
+		}
+		g.printf("}\n")
+	case *types.Array:
+		g.printf("for i := range *in {\nin, out := &(*in)[i], &(*out)[i]\n")
+		g.copyParams(x.Elem())
+		g.printf("}\n")
+	case *types.Map:
+		g.printf("if *in != nil {\nm := make(%s, len(*in))\n", lit(x))
+		g.printf("for key, val := range *in {\n")
+		if mutable(x.Elem()) {
+			g.printf("in, out := &val, new(%s)\n*out = *in\n", lit(x.Elem()))
+			g.copyParams(x.Elem())
+			g.printf("m[key] = *out\n")
+		} else {
+			g.printf("m[key] = val\n")
+		}
+		g.printf("}\n*out = m\n}\n")
+	case *types.Struct:
+		for i := range x.NumFields() {
+			if f := x.Field(i); mutable(f.Type()) {
+				g.printf("{\nin, out := &in.%s, &out.%s\n", f.Name(), f.Name())
+				g.copyParams(f.Type())
+				g.printf("}\n")
+			}
+		}
+	}
+}
+
+// opaque reports if t is declared in other package and treated as value
+func (g *generator) opaque(t typx.Type) bool {
+	return t.Name() != "" && t.PkgPath() != "" && t.PkgPath() != g.imports.Local()
+}
+
+// shallow reports if values of t are copied by assignment. interface and
+// function values are shallow, since their dynamic values cannot be copied
+// without knowing their types. values cannot reference themselves without
+// pointers, so the targets are checked structurally without recursion.
+func (g *generator) shallow(t typx.Type) bool {
+	if g.opaque(t) {
+		_, ok := g.method(t, "DeepCopy")
+		return !ok
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return false
+	case reflect.Array:
+		return g.shallow(t.Elem())
+	case reflect.Struct:
+		fs, params := fields(t)
+		for i, f := range fs {
+			if params[i] != nil && mutable(params[i]) || params[i] == nil && !g.shallow(f.Type()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// comparable reports if values of t can be compared by ==
+func (g *generator) comparable(t typx.Type) bool {
+	if !t.Comparable() {
+		return false
+	}
+	if g.opaque(t) {
+		_, ok := g.method(t, "Equal")
+		return !ok
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return false
+	case reflect.Array:
+		return g.comparable(t.Elem())
+	case reflect.Struct:
+		fs, params := fields(t)
+		for i, f := range fs {
+			if params[i] != nil || !g.comparable(f.Type()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// method returns method of opaque type t or *t named name. `DeepCopy` should
+// return t or *t and `Equal` should accept t or *t and return bool.
+func (g *generator) method(t typx.Type, name string) (typx.Method, bool) {
+	for _, x := range []typx.Type{t, pointer(t)} {
+		m, ok := x.MethodByName(name)
+		if !ok {
+			continue
+		}
+		// method type includes receiver
+		s := m.Type()
+		switch name {
+		case "DeepCopy":
+			if s.NumIn() == 1 && s.NumOut() == 1 && typx.Identical(s.Out(0), x) {
+				return m, true
+			}
+		case "Equal":
+			if s.NumIn() == 2 && s.NumOut() == 1 && typx.Identical(s.In(1), x) && s.Out(0).Kind() == reflect.Bool {
+				return m, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// inline starts expanding named type t which is not a target
+func (g *generator) inline(t typx.Type) error {
+	if t.Name() == "" {
+		return nil
+	}
+	if slices.Contains(g.inlining, t.String()) {
+		return fmt.Errorf("recursive type %s should be generated together", t)
+	}
+	g.inlining = append(g.inlining, t.String())
+	return nil
+}
+
+func (g *generator) inlined(t typx.Type) {
+	if t.Name() != "" {
+		g.inlining = g.inlining[:len(g.inlining)-1]
+	}
+}
+
+func pointer(t typx.Type) typx.Type {
+	switch x := t.Unwrap().(type) {
+	case reflect.Type:
+		return typx.NewRType(reflect.PointerTo(x))
+	default:
+		return typx.NewTType(types.NewPointer(x.(types.Type)))
+	}
+}
+
+// copy emits statements copying *in to *out deeply, where in and out are
+// pointers of t and *out was assigned by *in.
+func (g *generator) copy(t typx.Type) error {
+	if x := g.target(t); x != nil {
+		if g.recursive && x.recursive {
+			g.printf("in.deepCopyInto(out, seen)\n")
+		} else {
+			g.printf("in.DeepCopyInto(out)\n")
+		}
+		return nil
+	}
+	if g.opaque(t) {
+		if m, ok := g.method(t, "DeepCopy"); ok {
+			if m.Type().In(0).Kind() == reflect.Pointer {
+				g.printf("*out = *in.DeepCopy()\n")
+			} else {
+				g.printf("*out = in.DeepCopy()\n")
+			}
+		}
+		return nil
+	}
+
+	if err := g.inline(t); err != nil {
+		return err
+	}
+	defer g.inlined(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.copyStruct(t)
+	case reflect.Array:
+		g.printf("for i := range *in {\nin, out := &(*in)[i], &(*out)[i]\n")
+		if err := g.copy(t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Pointer:
+		elem := t.Elem()
+		g.printf("if *in != nil {\n")
+		if x := g.target(elem); x != nil && !g.shallow(elem) {
+			if g.recursive && x.recursive {
+				g.printf("*out = (*in).deepCopy(seen)\n")
+			} else {
+				g.printf("*out = (*in).DeepCopy()\n")
+			}
+		} else {
+			g.printf("*out = new(%s)\n**out = **in\n", g.lit(elem))
+			if !g.shallow(elem) {
+				g.printf("in, out := *in, *out\n")
+				if err := g.copy(elem); err != nil {
+					return err
+				}
+			}
+		}
+		g.printf("}\n")
+	case reflect.Slice:
+		g.printf("if *in != nil {\n*out = make(%s, len(*in))\ncopy(*out, *in)\n", g.lit(t))
+		if !g.shallow(t.Elem()) {
+			g.printf("for i := range *in {\nin, out := &(*in)[i], &(*out)[i]\n")
+			if err := g.copy(t.Elem()); err != nil {
+				return err
+			}
+			g.printf("}\n")
+		}
+		g.printf("}\n")
+	case reflect.Map:
+		g.printf("if *in != nil {\nm := make(%s, len(*in))\n", g.lit(t))
+		g.printf("for key, val := range *in {\n")
+		if !g.shallow(t.Elem()) {
+			g.printf("in, out := &val, new(%s)\n*out = *in\n", g.lit(t.Elem()))
+			if err := g.copy(t.Elem()); err != nil {
+				return err
+			}
+			g.printf("m[key] = *out\n")
+		} else {
+			g.printf("m[key] = val\n")
+		}
+		g.printf("}\n*out = m\n}\n")
+	}
+	return nil
+}
+
+func (g *generator) copyStruct(t typx.Type) error {
+	fs, params := fields(t)
+	for i, f := range fs {
+		if params[i] != nil {
+			if mutable(params[i]) {
+				g.printf("{\nin, out := &in.%s, &out.%s\n", f.Name(), f.Name())
+				g.copyParams(params[i])
+				g.printf("}\n")
+			}
+			continue
+		}
+		if g.shallow(f.Type()) {
+			if f.Type().Kind() == reflect.Interface {
+				g.printf("// %s is copied by assignment, its dynamic value is shared\n", f.Name())
+			}
+			continue
+		}
+		g.printf("{\nin, out := &in.%s, &out.%s\n", f.Name(), f.Name())
+		if err := g.copy(f.Type()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	}
+	return nil
+}
+
+// equal emits statements returning false if *a and *b are not deeply equal,
+// where a and b are pointers of t.
+func (g *generator) equal(t typx.Type) error {
+	if g.comparable(t) {
+		g.printf("if *a != *b {\nreturn false\n}\n")
+		return nil
+	}
+	if x := g.target(t); x != nil {
+		if g.recursive && x.recursive {
+			g.printf("if !a.equal(b, seen) {\nreturn false\n}\n")
+		} else {
+			g.printf("if !a.Equal(b) {\nreturn false\n}\n")
+		}
+		return nil
+	}
+	if g.opaque(t) {
+		if m, ok := g.method(t, "Equal"); ok {
+			if m.Type().In(0).Kind() == reflect.Pointer {
+				g.printf("if !a.Equal(b) {\nreturn false\n}\n")
+			} else {
+				g.printf("if !(*a).Equal(*b) {\nreturn false\n}\n")
+			}
+		} else {
+			g.deepEqual("*a", "*b")
+		}
+		return nil
+	}
+
+	if err := g.inline(t); err != nil {
+		return err
+	}
+	defer g.inlined(t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.equalStruct(t)
+	case reflect.Array:
+		g.printf("for i := range *a {\na, b := &(*a)[i], &(*b)[i]\n")
+		if err := g.equal(t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Pointer:
+		g.printf("if (*a == nil) != (*b == nil) {\nreturn false\n}\n")
+		g.printf("if *a != nil && *a != *b {\na, b := *a, *b\n")
+		if err := g.equal(t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Slice:
+		g.printf("if len(*a) != len(*b) || (*a == nil) != (*b == nil) {\nreturn false\n}\n")
+		g.printf("for i := range *a {\na, b := &(*a)[i], &(*b)[i]\n")
+		if err := g.equal(t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Map:
+		g.printf("if len(*a) != len(*b) || (*a == nil) != (*b == nil) {\nreturn false\n}\n")
+		g.printf("for key, va := range *a {\nvb, ok := (*b)[key]\nif !ok {\nreturn false\n}\n")
+		g.printf("a, b := &va, &vb\n")
+		if err := g.equal(t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Func:
+		// functions are equal only if both are nil
+		g.printf("if *a != nil || *b != nil {\nreturn false\n}\n")
+	default:
+		g.deepEqual("*a", "*b")
+	}
+	return nil
+}
+
+func (g *generator) deepEqual(a, b string) {
+	g.printf("if !%s.DeepEqual(%s, %s) {\nreturn false\n}\n", g.imports.PackageName("reflect"), a, b)
+}
+
+func (g *generator) equalStruct(t typx.Type) error {
+	fs, params := fields(t)
+	for i, f := range fs {
+		if params[i] != nil {
+			g.deepEqual("a."+f.Name(), "b."+f.Name())
+			continue
+		}
+		g.printf("{\na, b := &a.%s, &b.%s\n", f.Name(), f.Name())
+		if err := g.equal(f.Type()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	}
+	return nil
+}
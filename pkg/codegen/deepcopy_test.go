@@ -0,0 +1,177 @@
+package codegen_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+	"github.com/xoctopus/typx/testdata/deepcopy"
+)
+
+func TestGenerateDeepCopy(t *testing.T) {
+	const path = local + "/deepcopy"
+
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+
+	types := make([]typx.Type, 0)
+	for _, name := range []string{"Tree", "Department", "Employee", "Object", "Meta", "Point"} {
+		x, ok := p.Type(name)
+		Expect(t, ok, BeTrue())
+		types = append(types, x)
+	}
+
+	imports := codegen.NewImports(path)
+	ctx := typx.CtxPkgNamer.With(context.Background(), imports)
+	code, err := codegen.GenerateDeepCopy(ctx, types...)
+	Expect(t, err, BeNil[error]())
+
+	generated := "// Code generated by typx codegen. DO NOT EDIT.\n\n" +
+		"package deepcopy\n\n" + imports.String() + "\n" + code
+	filename := "../../testdata/deepcopy/zz_generated.deepcopy.go"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		Expect(t, os.WriteFile(filename, []byte(generated), 0o644), BeNil[error]())
+	}
+	golden, err := os.ReadFile(filename)
+	Expect(t, err, BeNil[error]())
+	Expect(t, generated, Equal(string(golden)))
+
+	t.Run("InvalidContext", func(t *testing.T) {
+		_, err := codegen.GenerateDeepCopy(context.Background(), types...)
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("InvalidTypes", func(t *testing.T) {
+		// declared in test function cannot be loaded from source
+		type declared struct{ Name string }
+		for _, x := range []typx.Type{
+			typx.NewRType(reflect.TypeFor[declared]()),
+			typx.NewRType(reflect.TypeFor[int]()),
+			typx.NewRType(reflect.TypeFor[struct{}]()),
+			typx.NewRType(reflect.TypeFor[testdata.Tagged]()),
+			typx.NewRType(reflect.TypeFor[testdata.BTreeNode[int]]()),
+		} {
+			_, err := codegen.GenerateDeepCopy(ctx, x)
+			Expect(t, err, NotBeNil[error]())
+		}
+	})
+
+	t.Run("Inline", func(t *testing.T) {
+		imports := codegen.NewImports(local)
+		ctx := typx.CtxPkgNamer.With(context.Background(), imports)
+		// Department references itself via Employee, which is inlined
+		x := typx.NewRType(reflect.TypeFor[testdata.Department]())
+		_, err := codegen.GenerateDeepCopy(ctx, x)
+		Expect(t, err, BeNil[error]())
+
+		// BTreeNode[int] is recursive and cannot be inlined
+		x = typx.NewRType(reflect.TypeFor[testdata.Composites]())
+		_, err = codegen.GenerateDeepCopy(ctx, x)
+		Expect(t, err, NotBeNil[error]())
+	})
+}
+
+func TestGeneratedDeepCopy(t *testing.T) {
+	t.Run("Tree", func(t *testing.T) {
+		root := &deepcopy.Tree[int]{Value: 1, Values: []int{1, 2}, Index: map[string][]int{"a": {1}}}
+		root.Children = []*deepcopy.Tree[int]{
+			{Value: 2, Parent: root},
+			{Value: 3, Parent: root},
+		}
+		root.Children[1].Children = []*deepcopy.Tree[int]{root}
+
+		x := root.DeepCopy()
+		Expect(t, x != root, BeTrue())
+		Expect(t, x.Children[0].Parent == x, BeTrue())
+		Expect(t, x.Children[1].Children[0] == x, BeTrue())
+		Expect(t, x.Equal(root), BeTrue())
+
+		x.Children[0].Value = 4
+		Expect(t, root.Children[0].Value, Equal(2))
+		Expect(t, x.Equal(root), BeFalse())
+
+		// containers of type parameter are copied
+		x = root.DeepCopy()
+		x.Values[0] = 3
+		Expect(t, root.Values[0], Equal(1))
+		Expect(t, x.Equal(root), BeFalse())
+		x = root.DeepCopy()
+		x.Index["a"][0] = 2
+		Expect(t, root.Index["a"][0], Equal(1))
+		Expect(t, x.Equal(root), BeFalse())
+		Expect(t, (*deepcopy.Tree[int])(nil).DeepCopy() == nil, BeTrue())
+	})
+
+	t.Run("Department", func(t *testing.T) {
+		d := &deepcopy.Department{Name: "dev"}
+		d.Manager = &deepcopy.Employee{Name: "alice", Department: d}
+		d.Members = []*deepcopy.Employee{d.Manager, {Name: "bob", Department: d}}
+
+		x := d.DeepCopy()
+		Expect(t, x.Manager.Department == x, BeTrue())
+		Expect(t, x.Members[0] == x.Manager, BeTrue())
+		Expect(t, x.Equal(d), BeTrue())
+
+		x.Members[1].Name = "carol"
+		Expect(t, d.Members[1].Name, Equal("bob"))
+		Expect(t, x.Equal(d), BeFalse())
+	})
+
+	t.Run("Object", func(t *testing.T) {
+		label, i := "label", 1
+		ptr := &i
+		now := time.Now()
+		o := &deepcopy.Object{
+			Meta:      deepcopy.Meta{Annotations: map[string]string{"k": "v"}},
+			ID:        1,
+			Tags:      []string{"a"},
+			Labels:    map[string]*string{"k": &label},
+			Matrix:    [2][]int{{1}, {2}},
+			Nested:    map[string][]deepcopy.Point{"k": {{X: 1}}},
+			Ptr:       &deepcopy.Point{X: 1},
+			PtrPtr:    &ptr,
+			Any:       []int{1},
+			Time:      now,
+			CreatedAt: &now,
+			Tree:      &deepcopy.Tree[int]{Value: 1},
+		}
+		o.Inner.Values = map[string]int{"k": 1}
+
+		x := o.DeepCopy()
+		Expect(t, x.Equal(o), BeTrue())
+
+		for _, mutate := range []func(*deepcopy.Object){
+			func(x *deepcopy.Object) { x.Annotations["k"] = "x" },
+			func(x *deepcopy.Object) { x.Tags[0] = "x" },
+			func(x *deepcopy.Object) { *x.Labels["k"] = "x" },
+			func(x *deepcopy.Object) { x.Matrix[1][0] = 3 },
+			func(x *deepcopy.Object) { x.Nested["k"][0].Y = 1 },
+			func(x *deepcopy.Object) { x.Ptr.Y = 1 },
+			func(x *deepcopy.Object) { **x.PtrPtr = 2 },
+			func(x *deepcopy.Object) { x.Inner.Values["k"] = 2 },
+			func(x *deepcopy.Object) { x.Tree.Value = 2 },
+			func(x *deepcopy.Object) { x.Tags = nil },
+			func(x *deepcopy.Object) { x.Func = func() {} },
+		} {
+			x := o.DeepCopy()
+			mutate(x)
+			Expect(t, x.Equal(o), BeFalse())
+			Expect(t, o.DeepCopy().Equal(o), BeTrue())
+		}
+		Expect(t, *o.Labels["k"], Equal("label"))
+		Expect(t, i, Equal(1))
+
+		// dynamic value of interface is shared
+		o.Any = &deepcopy.Point{X: 1}
+		x = o.DeepCopy()
+		x.Any.(*deepcopy.Point).X = 2
+		Expect(t, o.Any.(*deepcopy.Point).X, Equal(2))
+	})
+}
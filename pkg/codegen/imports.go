@@ -0,0 +1,114 @@
+package codegen
+
+import (
+	"go/token"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NewImports creates Imports for code generated in package local. the types
+// declared in local package are referenced without package name.
+func NewImports(local string) *Imports {
+	return &Imports{
+		local: local,
+		names: make(map[string]string),
+		paths: make(map[string]string),
+	}
+}
+
+// Imports names the imported packages of generated code. it implements
+// typx.PkgNamer, use it with typx.CtxPkgNamer to dump type literals.
+type Imports struct {
+	local string
+	// names mapping package path to its name
+	names map[string]string
+	// paths mapping package name to its path
+	paths map[string]string
+}
+
+// Local returns the package path of generated code
+func (i *Imports) Local() string {
+	return i.local
+}
+
+// PackageName returns the unique name of imported package. it returns empty
+// for local package.
+func (i *Imports) PackageName(path string) string {
+	if path == "" || path == i.local {
+		return ""
+	}
+	if name, ok := i.names[path]; ok {
+		return name
+	}
+
+	base := packageName(path)
+	name := base
+	for n := 1; ; n++ {
+		if _, ok := i.paths[name]; !ok && token.Lookup(name) == token.IDENT {
+			break
+		}
+		name = base + strconv.Itoa(n)
+	}
+	i.names[path] = name
+	i.paths[name] = path
+	return name
+}
+
+// Paths returns sorted imported package paths
+func (i *Imports) Paths() []string {
+	return slices.Sorted(maps.Keys(i.names))
+}
+
+// String returns the import declaration of imported packages. the package
+// name is aliased if it differs from the last element of path.
+func (i *Imports) String() string {
+	if len(i.names) == 0 {
+		return ""
+	}
+	b := strings.Builder{}
+	b.WriteString("import (\n")
+	for _, p := range i.Paths() {
+		b.WriteString("\t")
+		if name := i.names[p]; name != path.Base(p) {
+			b.WriteString(name + " ")
+		}
+		b.WriteString(strconv.Quote(p) + "\n")
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// packageName guesses package name from package path, eg: the name of
+// `github.com/x/go-yaml/v3` is `yaml`
+func packageName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && isMajorVersion(name) {
+		name = parts[len(parts)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(name, ".go")
+
+	b := strings.Builder{}
+	for _, c := range name {
+		if c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+			b.WriteRune(c)
+		}
+	}
+	name = b.String()
+	if name == "" || '0' <= name[0] && name[0] <= '9' {
+		name = "pkg" + name
+	}
+	return name
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
@@ -0,0 +1,40 @@
+package codegen_test
+
+import (
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+)
+
+func TestImports(t *testing.T) {
+	imports := codegen.NewImports("github.com/xoctopus/typx/testdata")
+	Expect(t, imports.Local(), Equal("github.com/xoctopus/typx/testdata"))
+	Expect(t, imports.String(), Equal(""))
+
+	Expect(t, imports.PackageName("github.com/xoctopus/typx/testdata"), Equal(""))
+	Expect(t, imports.PackageName(""), Equal(""))
+	Expect(t, imports.PackageName("net"), Equal("net"))
+	Expect(t, imports.PackageName("net"), Equal("net"))
+	Expect(t, imports.PackageName("github.com/x/net"), Equal("net1"))
+	Expect(t, imports.PackageName("gopkg.in/go-yaml/v3"), Equal("yaml"))
+	Expect(t, imports.PackageName("github.com/x/type"), Equal("type1"))
+	Expect(t, imports.PackageName("github.com/x/3d"), Equal("pkg3d"))
+
+	Expect(t, imports.Paths(), Equal([]string{
+		"github.com/x/3d",
+		"github.com/x/net",
+		"github.com/x/type",
+		"gopkg.in/go-yaml/v3",
+		"net",
+	}))
+	Expect(t, imports.String(), Equal(`import (
+	pkg3d "github.com/x/3d"
+	net1 "github.com/x/net"
+	type1 "github.com/x/type"
+	yaml "gopkg.in/go-yaml/v3"
+	"net"
+)
+`))
+}
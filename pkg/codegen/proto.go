@@ -0,0 +1,401 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// GenerateProto generates proto3 definitions of named struct types ts and the
+// named types referenced by them. the proto package and message names reuse
+// the wrapped type id, eg: message of `Page[Item]` declared in local package
+// of *Imports carried by ctx is named `Page_Item`, and message of `net.Addr` is
+// named `net_Addr`.
+//
+// field numbers are taken from `protobuf` tag if present, others are assigned
+// by declaration order with the unused numbers. it returns error if a tagged
+// number is out of range or reserved (19000-19999), or an enum value is out of
+// int32 range. slices are mapped to repeated fields, maps to map fields,
+// pointers to optional fields, named integer types with exported constants to
+// enums, anonymous structs to nested messages, and time.Time and time.Duration
+// to the well-known types.
+func GenerateProto(ctx context.Context, ts ...typx.Type) (string, error) {
+	namer, _ := typx.CtxPkgNamer.From(ctx)
+	imports, ok := namer.(*Imports)
+	if !ok {
+		return "", fmt.Errorf("expect *Imports as package namer in context")
+	}
+
+	g := &protogen{
+		local:    typi.EncodePath(imports.Local()) + ".",
+		declared: make(map[string]bool),
+		imports:  make(map[string]bool),
+	}
+	for _, t := range ts {
+		if r, ok := t.Unwrap().(reflect.Type); ok {
+			x, err := typi.TryNewTTByRT(r)
+			if err != nil {
+				return "", err
+			}
+			t = typx.NewTTypeContext(ctx, x)
+		}
+		if n, ok := t.Unwrap().(*types.Named); ok && n.TypeParams().Len() > n.TypeArgs().Len() {
+			return "", fmt.Errorf("%s is uninstantiated", t)
+		}
+		if t.PkgPath() == "" || t.Name() == "" || t.Kind() != reflect.Struct && len(g.enum(t)) == 0 {
+			return "", fmt.Errorf("%s is not a named struct or enum type", t)
+		}
+		g.enqueue(t)
+	}
+
+	decls := make([]string, 0)
+	for len(g.pending) > 0 {
+		t := g.pending[0]
+		g.pending = g.pending[1:]
+		decl, err := g.declare(t)
+		if err != nil {
+			return "", fmt.Errorf("failed to declare %s: %w", t, err)
+		}
+		decls = append(decls, decl)
+	}
+
+	b := strings.Builder{}
+	b.WriteString("syntax = \"proto3\";\n\n")
+	b.WriteString("package " + strings.TrimSuffix(g.local, ".") + ";\n\n")
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		for _, path := range paths {
+			b.WriteString("import " + strconv.Quote(path) + ";\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("option go_package = " + strconv.Quote(imports.Local()) + ";\n")
+	for _, decl := range decls {
+		b.WriteString("\n" + decl)
+	}
+	return b.String(), nil
+}
+
+type protogen struct {
+	// local is the wrapped path of local package with dot suffix
+	local    string
+	declared map[string]bool
+	pending  []typx.Type
+	// imports are imported proto files
+	imports map[string]bool
+}
+
+// name returns message or enum name of named type t
+func (g *protogen) name(t typx.Type) string {
+	id := strings.ReplaceAll(typi.Wrap(t.Unwrap()), g.local, "")
+	b := strings.Builder{}
+	for _, c := range id {
+		if c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) {
+			b.WriteRune(c)
+		} else if s := b.String(); s != "" && s[len(s)-1] != '_' {
+			b.WriteRune('_')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+func (g *protogen) enqueue(t typx.Type) string {
+	name := g.name(t)
+	if !g.declared[name] {
+		g.declared[name] = true
+		g.pending = append(g.pending, t)
+	}
+	return name
+}
+
+// enum returns exported constants of named integer type t
+func (g *protogen) enum(t typx.Type) []*typx.EnumValue {
+	n, ok := t.Unwrap().(*types.Named)
+	if !ok || n.Obj().Pkg() == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+	e, err := typx.Enums(typx.NewPackage(n.Obj().Pkg()), t)
+	if err != nil {
+		return nil
+	}
+	return slices.DeleteFunc(slices.Clone(e.Values()), func(v *typx.EnumValue) bool {
+		return !token.IsExported(v.Name())
+	})
+}
+
+func (g *protogen) declare(t typx.Type) (string, error) {
+	name := g.name(t)
+	if t.Kind() == reflect.Struct {
+		return g.message(name, t, "")
+	}
+
+	prefix := strings.ToUpper(snake(name))
+	values := make([]*typx.EnumValue, 0)
+	numbers := make([]int64, 0)
+	for _, v := range g.enum(t) {
+		n, ok := constant.Int64Val(v.Value())
+		if !ok || n < math.MinInt32 || n > math.MaxInt32 {
+			return "", fmt.Errorf("enum value %s = %s is out of int32 range", v.Name(), v.Value())
+		}
+		if slices.Contains(numbers, n) {
+			continue
+		}
+		numbers = append(numbers, n)
+		values = append(values, v)
+	}
+
+	b := strings.Builder{}
+	b.WriteString("enum " + name + " {\n")
+	// the first enum value must be zero in proto3
+	if i := slices.Index(numbers, 0); i < 0 {
+		b.WriteString("  " + prefix + "_UNSPECIFIED = 0;\n")
+	} else {
+		zero := values[i]
+		values = slices.Insert(slices.Delete(values, i, i+1), 0, zero)
+	}
+	for _, v := range values {
+		n, _ := constant.Int64Val(v.Value())
+		x := strings.ToUpper(snake(v.Name()))
+		if !strings.HasPrefix(x, prefix+"_") {
+			x = prefix + "_" + x
+		}
+		_, _ = fmt.Fprintf(&b, "  %s = %d;\n", x, n)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// maxFieldNumber is the largest field number of protobuf
+const maxFieldNumber = 1<<29 - 1
+
+// reserved reports if field number n is reserved by protobuf implementation
+func reserved(n int) bool {
+	return n >= 19000 && n <= 19999
+}
+
+type protoField struct {
+	name   string
+	number int
+	f      typx.StructField
+}
+
+// message returns message declaration of struct t, anonymous structs are
+// declared as nested messages
+func (g *protogen) message(name string, t typx.Type, indent string) (string, error) {
+	fields := make([]*protoField, 0, t.NumField())
+	used := make(map[int]bool)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, ok := f.Tag().Lookup("protobuf")
+		if f.PkgPath() != "" || tag == "-" {
+			continue
+		}
+		// embedded field is named by its type name without pointer
+		x := &protoField{name: snake(f.Name()), f: f}
+		if ok {
+			for _, opt := range strings.Split(tag, ",") {
+				if n, err := strconv.Atoi(opt); err == nil && x.number == 0 {
+					x.number = n
+				} else if v, ok := strings.CutPrefix(opt, "name="); ok {
+					x.name = v
+				}
+			}
+		}
+		if x.number != 0 {
+			if x.number < 1 || x.number > maxFieldNumber {
+				return "", fmt.Errorf("field number %d of %s is out of range", x.number, x.name)
+			}
+			if reserved(x.number) {
+				return "", fmt.Errorf("field number %d of %s is reserved", x.number, x.name)
+			}
+			if used[x.number] {
+				return "", fmt.Errorf("field number %d of %s is duplicated", x.number, x.name)
+			}
+			used[x.number] = true
+		}
+		fields = append(fields, x)
+	}
+	next := 1
+	for _, x := range fields {
+		if x.number != 0 {
+			continue
+		}
+		for used[next] || reserved(next) {
+			next++
+		}
+		x.number = next
+		used[next] = true
+	}
+
+	nested := strings.Builder{}
+	body := strings.Builder{}
+	for _, x := range fields {
+		typ, err := g.field(x.f.Name(), x.f.Type(), indent+"  ", &nested)
+		if err != nil {
+			return "", fmt.Errorf("field %s: %w", x.f.Name(), err)
+		}
+		_, _ = fmt.Fprintf(&body, "%s  %s %s = %d;\n", indent, typ, x.name, x.number)
+	}
+
+	b := strings.Builder{}
+	b.WriteString(indent + "message " + name + " {\n")
+	b.WriteString(nested.String())
+	b.WriteString(body.String())
+	b.WriteString(indent + "}\n")
+	return b.String(), nil
+}
+
+// field returns the type of field with label
+func (g *protogen) field(name string, t typx.Type, indent string, nested *strings.Builder) (string, error) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		if !singular(t.Elem()) {
+			return "", fmt.Errorf("unsupported pointer type %s", t)
+		}
+		x, err := g.typ(name, t.Elem(), indent, nested)
+		if err != nil {
+			return "", err
+		}
+		return "optional " + x, nil
+	case reflect.Slice, reflect.Array:
+		if bytesType(t) {
+			return "bytes", nil
+		}
+		elem := t.Elem()
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if !singular(elem) {
+			return "", fmt.Errorf("unsupported repeated type %s", t)
+		}
+		x, err := g.typ(name, elem, indent, nested)
+		if err != nil {
+			return "", err
+		}
+		return "repeated " + x, nil
+	case reflect.Map:
+		key := t.Key()
+		switch key.Kind() {
+		case reflect.Bool, reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return "", fmt.Errorf("unsupported map key type %s", key)
+		}
+		elem := t.Elem()
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if !singular(elem) {
+			return "", fmt.Errorf("unsupported map value type %s", t.Elem())
+		}
+		k, _ := scalarType(key)
+		v, err := g.typ(name, elem, indent, nested)
+		if err != nil {
+			return "", err
+		}
+		return "map<" + k + ", " + v + ">", nil
+	default:
+		return g.typ(name, t, indent, nested)
+	}
+}
+
+// typ returns message, enum or scalar type name of t
+func (g *protogen) typ(name string, t typx.Type, indent string, nested *strings.Builder) (string, error) {
+	switch {
+	case t.PkgPath() == "time" && t.Name() == "Time":
+		g.imports["google/protobuf/timestamp.proto"] = true
+		return "google.protobuf.Timestamp", nil
+	case t.PkgPath() == "time" && t.Name() == "Duration":
+		g.imports["google/protobuf/duration.proto"] = true
+		return "google.protobuf.Duration", nil
+	case t.Kind() == reflect.Struct && t.Name() == "":
+		decl, err := g.message(name, t, indent)
+		if err != nil {
+			return "", err
+		}
+		nested.WriteString(decl)
+		return name, nil
+	case t.Kind() == reflect.Struct || len(g.enum(t)) > 0:
+		return g.enqueue(t), nil
+	case bytesType(t):
+		return "bytes", nil
+	}
+	if x, ok := scalarType(t); ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// singular reports if t is mapped to a type which is neither repeated nor map
+func singular(t typx.Type) bool {
+	_, ok := scalarType(t)
+	return ok || t.Kind() == reflect.Struct || bytesType(t)
+}
+
+// bytesType reports if t is mapped to bytes
+func bytesType(t typx.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+func scalarType(t typx.Type) (string, bool) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool", true
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return "int32", true
+	case reflect.Int, reflect.Int64:
+		return "int64", true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "uint32", true
+	case reflect.Uint, reflect.Uint64:
+		return "uint64", true
+	case reflect.Float32:
+		return "float", true
+	case reflect.Float64:
+		return "double", true
+	case reflect.String:
+		return "string", true
+	default:
+		return "", false
+	}
+}
+
+// snake converts camel case name to snake case, eg: `CreatedAt` to
+// `created_at` and `HTTPServer` to `http_server`
+func snake(name string) string {
+	runes := []rune(name)
+	b := strings.Builder{}
+	for i, c := range runes {
+		if unicode.IsUpper(c) && i > 0 && runes[i-1] != '_' {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				i+1 < len(runes) && unicode.IsUpper(prev) && unicode.IsLower(runes[i+1]) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
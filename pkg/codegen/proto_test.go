@@ -0,0 +1,91 @@
+package codegen_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestGenerateProto(t *testing.T) {
+	p, err := typx.Load(local)
+	Expect(t, err, BeNil[error]())
+	tt, ok := p.Type("Order")
+	Expect(t, ok, BeTrue())
+	rt := typx.NewRType(reflect.TypeFor[testdata.Order]())
+
+	generated := make([]string, 0, 2)
+	for _, x := range []typx.Type{rt, tt} {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		code, err := codegen.GenerateProto(ctx, x)
+		Expect(t, err, BeNil[error]())
+		generated = append(generated, code)
+	}
+	Expect(t, generated[0], Equal(generated[1]))
+
+	filename := "../../testdata/proto/order.proto"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		Expect(t, os.WriteFile(filename, []byte(generated[0]), 0o644), BeNil[error]())
+	}
+	golden, err := os.ReadFile(filename)
+	Expect(t, err, BeNil[error]())
+	Expect(t, generated[0], Equal(string(golden)))
+
+	t.Run("Shipment", func(t *testing.T) {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		code, err := codegen.GenerateProto(ctx, typx.NewRType(reflect.TypeFor[testdata.Shipment]()))
+		Expect(t, err, BeNil[error]())
+		// embedded pointer is named by its type name
+		Expect(t, strings.Contains(code, `message Shipment {
+  optional OrderItem order_item = 1;
+  string carrier = 2;
+  Priority priority = 3;
+}`), BeTrue())
+		// zero value is the first
+		Expect(t, strings.Contains(code, `enum Priority {
+  PRIORITY_NONE = 0;
+  PRIORITY_HIGH = 1;
+}`), BeTrue())
+	})
+
+	t.Run("InvalidContext", func(t *testing.T) {
+		_, err := codegen.GenerateProto(context.Background(), rt)
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("InvalidTypes", func(t *testing.T) {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		page, _ := p.Type("Page")
+		// declared in test function cannot be loaded from source
+		type declared struct{ Name string }
+		for _, x := range []typx.Type{
+			typx.NewRType(reflect.TypeFor[declared]()),
+			typx.NewRType(reflect.TypeFor[int]()),
+			typx.NewRType(reflect.TypeFor[struct{}]()),
+			typx.NewRType(reflect.TypeFor[testdata.Level]()),
+			typx.NewRType(reflect.TypeFor[testdata.Article]()),
+			page,
+		} {
+			_, err := codegen.GenerateProto(ctx, x)
+			Expect(t, err, NotBeNil[error]())
+		}
+	})
+
+	t.Run("InvalidNumbers", func(t *testing.T) {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		_, err := codegen.GenerateProto(ctx, typx.NewRType(reflect.TypeFor[testdata.ReservedField]()))
+		Expect(t, err, NotBeNil[error]())
+		Expect(t, strings.Contains(err.Error(), "field number 19000 of name is reserved"), BeTrue())
+
+		_, err = codegen.GenerateProto(ctx, typx.NewRType(reflect.TypeFor[testdata.Overflowed]()))
+		Expect(t, err, NotBeNil[error]())
+		Expect(t, strings.Contains(err.Error(), "OVERFLOWED_LARGE = 2147483648 is out of int32 range"), BeTrue())
+	})
+}
@@ -0,0 +1,343 @@
+package codegen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/xoctopus/typx/internal/jsonfields"
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// GenerateTypeScript generates TypeScript declarations of named types ts and
+// the named types referenced by them, following encoding/json rules.
+//
+// types declared in the local package of *Imports carried by ctx are declared
+// at top level, others are declared in namespaces named by *Imports, which is
+// the same naming as TypeLit. structs are declared as interfaces with embedded
+// structs flattened, generic types are declared as generic interfaces or types,
+// named basic types with exported constants are declared as union of literal
+// types, and other named types are declared as type aliases.
+func GenerateTypeScript(ctx context.Context, ts ...typx.Type) (string, error) {
+	namer, _ := typx.CtxPkgNamer.From(ctx)
+	imports, ok := namer.(*Imports)
+	if !ok {
+		return "", fmt.Errorf("expect *Imports as package namer in context")
+	}
+
+	g := &tsgen{
+		imports:  imports,
+		declared: make(map[*types.TypeName]bool),
+		decls:    make(map[string][]string),
+	}
+	for _, t := range ts {
+		x := t.Unwrap()
+		if r, ok := x.(reflect.Type); ok {
+			tt, err := typi.TryNewTTByRT(r)
+			if err != nil {
+				return "", err
+			}
+			x = tt
+		}
+		n, ok := types.Unalias(x.(types.Type)).(*types.Named)
+		if !ok || n.Obj().Pkg() == nil {
+			return "", fmt.Errorf("%s is not a named type", t)
+		}
+		g.enqueue(n)
+	}
+	for len(g.pending) > 0 {
+		n := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.declare(n); err != nil {
+			return "", fmt.Errorf("failed to declare %s: %w", n, err)
+		}
+	}
+
+	b := strings.Builder{}
+	b.WriteString(strings.Join(g.decls[imports.Local()], "\n"))
+	namespaces := make([]string, 0, len(g.decls))
+	for path := range g.decls {
+		if path != imports.Local() {
+			namespaces = append(namespaces, path)
+		}
+	}
+	slices.SortFunc(namespaces, func(a, b string) int {
+		return strings.Compare(imports.PackageName(a), imports.PackageName(b))
+	})
+	for _, path := range namespaces {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("export namespace " + imports.PackageName(path) + " {\n")
+		for i, decl := range g.decls[path] {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			for _, line := range strings.SplitAfter(decl, "\n") {
+				if line != "" {
+					b.WriteString("  " + line)
+				}
+			}
+		}
+		b.WriteString("}\n")
+	}
+	return b.String(), nil
+}
+
+type tsgen struct {
+	imports  *Imports
+	declared map[*types.TypeName]bool
+	pending  []*types.Named
+	// decls are declarations grouped by package path
+	decls map[string][]string
+}
+
+func (g *tsgen) enqueue(n *types.Named) {
+	n = n.Origin()
+	if !g.declared[n.Obj()] {
+		g.declared[n.Obj()] = true
+		g.pending = append(g.pending, n)
+	}
+}
+
+func (g *tsgen) declare(n *types.Named) error {
+	var (
+		obj  = n.Obj()
+		path = obj.Pkg().Path()
+		name = obj.Name()
+		decl string
+	)
+	if tparams := n.TypeParams(); tparams.Len() > 0 {
+		names := make([]string, tparams.Len())
+		for i := range names {
+			names[i] = tparams.At(i).Obj().Name()
+		}
+		name += "<" + strings.Join(names, ", ") + ">"
+	}
+
+	switch {
+	case path == "time" && obj.Name() == "Time":
+		decl = "export type " + name + " = string;\n"
+	case hasMethod(n, "MarshalJSON"):
+		decl = "export type " + name + " = unknown;\n"
+	case hasMethod(n, "MarshalText"):
+		decl = "export type " + name + " = string;\n"
+	default:
+		switch u := n.Underlying().(type) {
+		case *types.Struct:
+			fields := jsonfields.OfStruct(u)
+			if len(fields) == 0 {
+				decl = "export interface " + name + " {}\n"
+				break
+			}
+			b := strings.Builder{}
+			b.WriteString("export interface " + name + " {\n")
+			for _, f := range fields {
+				x, err := g.field(f)
+				if err != nil {
+					return err
+				}
+				b.WriteString("  " + x + ";\n")
+			}
+			b.WriteString("}\n")
+			decl = b.String()
+		case *types.Basic:
+			x, err := g.expr(u)
+			if err != nil {
+				return err
+			}
+			if values := enums(n); len(values) > 0 {
+				x = strings.Join(values, " | ")
+			}
+			decl = "export type " + name + " = " + x + ";\n"
+		default:
+			x, err := g.expr(u)
+			if err != nil {
+				return err
+			}
+			decl = "export type " + name + " = " + x + ";\n"
+		}
+	}
+	g.decls[path] = append(g.decls[path], decl)
+	return nil
+}
+
+// field returns TypeScript property signature of json field f
+func (g *tsgen) field(f jsonfields.Field[types.Type]) (string, error) {
+	key := f.Name
+	if !identifier(key) {
+		key = strconv.Quote(key)
+	}
+	if f.Omit {
+		key += "?"
+	}
+	if f.Quoted {
+		if _, ok := types.Unalias(f.Type).(*types.Pointer); ok {
+			return key + ": string | null", nil
+		}
+		return key + ": string", nil
+	}
+	x, err := g.expr(f.Type)
+	if err != nil {
+		return "", fmt.Errorf("field %s: %w", f.Name, err)
+	}
+	return key + ": " + x, nil
+}
+
+// expr returns TypeScript type expression of t
+func (g *tsgen) expr(t types.Type) (string, error) {
+	switch x := types.Unalias(t).(type) {
+	case *types.TypeParam:
+		return x.Obj().Name(), nil
+	case *types.Named:
+		if x.Obj().Pkg() == nil {
+			// error
+			return g.expr(x.Underlying())
+		}
+		g.enqueue(x)
+		name := x.Obj().Name()
+		if path := x.Obj().Pkg().Path(); path != g.imports.Local() {
+			name = g.imports.PackageName(path) + "." + name
+		}
+		if targs := x.TypeArgs(); targs.Len() > 0 {
+			args := make([]string, targs.Len())
+			for i := range args {
+				arg, err := g.expr(targs.At(i))
+				if err != nil {
+					return "", err
+				}
+				args[i] = arg
+			}
+			name += "<" + strings.Join(args, ", ") + ">"
+		}
+		return name, nil
+	case *types.Basic:
+		switch info := x.Info(); {
+		case info&types.IsBoolean != 0:
+			return "boolean", nil
+		case info&types.IsString != 0:
+			return "string", nil
+		case info&(types.IsInteger|types.IsFloat) != 0:
+			return "number", nil
+		}
+	case *types.Pointer:
+		elem, err := g.expr(x.Elem())
+		if err != nil {
+			return "", err
+		}
+		if strings.HasSuffix(elem, " | null") {
+			return elem, nil
+		}
+		return elem + " | null", nil
+	case *types.Slice:
+		if binary(x.Elem()) {
+			return "string", nil
+		}
+		return g.array(x.Elem())
+	case *types.Array:
+		return g.array(x.Elem())
+	case *types.Map:
+		// json object keys are always strings
+		_, param := types.Unalias(x.Key()).(*types.TypeParam)
+		key, ok := types.Unalias(x.Key()).Underlying().(*types.Basic)
+		if !param && !hasMethod(x.Key(), "MarshalText") && (!ok || key.Info()&(types.IsString|types.IsInteger) == 0) {
+			return "", fmt.Errorf("unsupported map key type %s", x.Key())
+		}
+		elem, err := g.expr(x.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "Record<string, " + elem + ">", nil
+	case *types.Interface:
+		return "unknown", nil
+	case *types.Struct:
+		fields := jsonfields.OfStruct(x)
+		if len(fields) == 0 {
+			return "{}", nil
+		}
+		props := make([]string, len(fields))
+		for i, f := range fields {
+			prop, err := g.field(f)
+			if err != nil {
+				return "", err
+			}
+			props[i] = prop
+		}
+		return "{ " + strings.Join(props, "; ") + " }", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+func (g *tsgen) array(elem types.Type) (string, error) {
+	x, err := g.expr(elem)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(x, " | null") {
+		x = "(" + x + ")"
+	}
+	return x + "[]", nil
+}
+
+// hasMethod reports if method name is in the method set of t
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// binary reports if slice of elem is encoded as base64 string
+func binary(elem types.Type) bool {
+	b, ok := elem.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8 && !hasMethod(elem, "MarshalJSON") && !hasMethod(elem, "MarshalText")
+}
+
+// enums returns TypeScript literals of exported constants of named basic type n
+func enums(n *types.Named) []string {
+	e, err := typx.Enums(typx.NewPackage(n.Obj().Pkg()), typx.NewTType(n))
+	if err != nil {
+		return nil
+	}
+	values := make([]string, 0, len(e.Values()))
+	for _, v := range e.Values() {
+		if !token.IsExported(v.Name()) {
+			continue
+		}
+		var lit string
+		switch x := v.Value(); x.Kind() {
+		case constant.String:
+			data, _ := json.Marshal(constant.StringVal(x))
+			lit = string(data)
+		case constant.Float:
+			f, _ := constant.Float64Val(x)
+			lit = strconv.FormatFloat(f, 'g', -1, 64)
+		case constant.Int, constant.Bool:
+			lit = x.ExactString()
+		default:
+			continue
+		}
+		if !slices.Contains(values, lit) {
+			values = append(values, lit)
+		}
+	}
+	return values
+}
+
+// identifier reports if s is a valid TypeScript identifier
+func identifier(s string) bool {
+	for i, c := range s {
+		if c != '_' && c != '$' && !unicode.IsLetter(c) && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,83 @@
+package codegen_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	p, err := typx.Load(local)
+	Expect(t, err, BeNil[error]())
+	tt, ok := p.Type("Task")
+	Expect(t, ok, BeTrue())
+	rt := typx.NewRType(reflect.TypeFor[testdata.Task]())
+
+	generated := make([]string, 0, 2)
+	for _, x := range []typx.Type{rt, tt} {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		code, err := codegen.GenerateTypeScript(ctx, x)
+		Expect(t, err, BeNil[error]())
+		generated = append(generated, code)
+	}
+	Expect(t, generated[0], Equal(generated[1]))
+
+	filename := "../../testdata/typescript/task.ts"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		Expect(t, os.WriteFile(filename, []byte(generated[0]), 0o644), BeNil[error]())
+	}
+	golden, err := os.ReadFile(filename)
+	Expect(t, err, BeNil[error]())
+	Expect(t, generated[0], Equal(string(golden)))
+
+	t.Run("Generic", func(t *testing.T) {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		x := typx.NewRType(reflect.TypeFor[testdata.Page[int]]())
+		code, err := codegen.GenerateTypeScript(ctx, x)
+		Expect(t, err, BeNil[error]())
+		Expect(t, code, Equal(`export interface Page<T> {
+  items: T[];
+  total: number;
+  next?: Page<T> | null;
+}
+`))
+	})
+
+	t.Run("Namespace", func(t *testing.T) {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports("net"))
+		x := typx.NewRType(reflect.TypeFor[testdata.Status]())
+		code, err := codegen.GenerateTypeScript(ctx, x)
+		Expect(t, err, BeNil[error]())
+		Expect(t, code, Equal(`export namespace testdata {
+  export type Status = 0 | 1 | 2 | 3;
+}
+`))
+	})
+
+	t.Run("InvalidContext", func(t *testing.T) {
+		_, err := codegen.GenerateTypeScript(context.Background(), rt)
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("InvalidTypes", func(t *testing.T) {
+		ctx := typx.CtxPkgNamer.With(context.Background(), codegen.NewImports(local))
+		// declared in test function cannot be loaded from source
+		type declared struct{ Name string }
+		for _, x := range []typx.Type{
+			typx.NewRType(reflect.TypeFor[declared]()),
+			typx.NewRType(reflect.TypeFor[int]()),
+			typx.NewRType(reflect.TypeFor[struct{}]()),
+			typx.NewRType(reflect.TypeFor[testdata.Generics[int]]()),
+		} {
+			_, err := codegen.GenerateTypeScript(ctx, x)
+			Expect(t, err, NotBeNil[error]())
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// Zero returns the Go expression of zero value of t, eg: `0`, `int64(0)`, `""`,
+// `(*pkg.T)(nil)` and `pkg.T{}`. the package names of type literals are named
+// by typx.CtxPkgNamer in ctx, eg: *Imports.
+func Zero(ctx context.Context, t typx.Type) string {
+	lit := typx.TypeLit(ctx, t.Unwrap())
+	k := t.Kind()
+	switch {
+	case k == reflect.Struct || k == reflect.Array:
+		return lit + "{}"
+	case nilable(k):
+		if t.Name() == "" && (k == reflect.Pointer || k == reflect.Func || k == reflect.Chan) {
+			return "(" + lit + ")(nil)"
+		}
+		return lit + "(nil)"
+	default:
+		v := zero(k)
+		if t.PkgPath() == "" && (k == reflect.Bool || k == reflect.String || k == reflect.Int) {
+			// default type of untyped constant
+			return v
+		}
+		return lit + "(" + v + ")"
+	}
+}
+
+// Literal returns the composite literal skeleton of t with every field set to
+// zero value, nested anonymous structs are expanded recursively. unexported
+// fields are included only when t is declared in local package of Imports. it
+// returns zero value expression if t is not a composite type.
+func Literal(ctx context.Context, t typx.Type) string {
+	b := &strings.Builder{}
+	literal(ctx, b, t, 0)
+	return b.String()
+}
+
+func literal(ctx context.Context, b *strings.Builder, t typx.Type, depth int) {
+	switch t.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice:
+		b.WriteString(typx.TypeLit(ctx, t.Unwrap()) + "{}")
+	case reflect.Struct:
+		b.WriteString(typx.TypeLit(ctx, t.Unwrap()) + "{")
+		n := 0
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !accessible(ctx, f) {
+				continue
+			}
+			n++
+			b.WriteString("\n" + strings.Repeat("\t", depth+1) + f.Name() + ": ")
+			value(ctx, b, f.Type(), depth+1)
+			b.WriteString(",")
+		}
+		if n > 0 {
+			b.WriteString("\n" + strings.Repeat("\t", depth))
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString(Zero(ctx, t))
+	}
+}
+
+// value writes zero value of field type t in composite literal
+func value(ctx context.Context, b *strings.Builder, t typx.Type, depth int) {
+	k := t.Kind()
+	switch {
+	case k == reflect.Struct && t.Name() == "":
+		literal(ctx, b, t, depth)
+	case k == reflect.Struct || k == reflect.Array || k == reflect.Map || k == reflect.Slice:
+		b.WriteString(typx.TypeLit(ctx, t.Unwrap()) + "{}")
+	case nilable(k):
+		b.WriteString("nil")
+	default:
+		b.WriteString(zero(k))
+	}
+}
+
+// accessible reports if field f can be set in generated code
+func accessible(ctx context.Context, f typx.StructField) bool {
+	if f.PkgPath() == "" {
+		return true
+	}
+	if namer, ok := typx.CtxPkgNamer.From(ctx); ok {
+		if x, ok := namer.(interface{ Local() string }); ok {
+			return x.Local() == f.PkgPath()
+		}
+	}
+	return false
+}
+
+func nilable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// zero returns untyped constant of zero value of basic kind
+func zero(k reflect.Kind) string {
+	switch k {
+	case reflect.Bool:
+		return "false"
+	case reflect.String:
+		return `""`
+	default:
+		return "0"
+	}
+}
@@ -0,0 +1,173 @@
+package codegen_test
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	. "github.com/xoctopus/x/testx"
+	"golang.org/x/tools/go/packages"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+const local = "github.com/xoctopus/typx/testdata"
+
+// check type checks expressions as package level variables in local package and
+// returns their types
+func check(t *testing.T, imports *codegen.Imports, exprs ...string) []types.Type {
+	b := strings.Builder{}
+	b.WriteString("package testdata\n\n" + imports.String() + "\n")
+	for i, expr := range exprs {
+		_, _ = fmt.Fprintf(&b, "var v%d = %s\n", i, expr)
+	}
+
+	p, err := typi.LoadPackage(local)
+	Expect(t, err, BeNil[error]())
+	f, err := parser.ParseFile(p.Fset, "generated.go", b.String(), 0)
+	Expect(t, err, BeNil[error]())
+
+	conf := types.Config{Importer: importer{p}}
+	pkg, err := conf.Check(local, p.Fset, append([]*ast.File{f}, p.Syntax...), nil)
+	Expect(t, err, BeNil[error]())
+
+	results := make([]types.Type, len(exprs))
+	for i := range exprs {
+		results[i] = pkg.Scope().Lookup(fmt.Sprintf("v%d", i)).Type()
+	}
+	return results
+}
+
+// importer imports dependencies of loaded package
+type importer struct {
+	p *packages.Package
+}
+
+func (i importer) Import(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	if x, ok := i.p.Imports[path]; ok {
+		return x.Types, nil
+	}
+	return nil, fmt.Errorf("package %s is not imported", path)
+}
+
+func TestZero(t *testing.T) {
+	cases := []struct {
+		typ    reflect.Type
+		expect string
+	}{
+		{reflect.TypeFor[bool](), "false"},
+		{reflect.TypeFor[string](), `""`},
+		{reflect.TypeFor[int](), "0"},
+		{reflect.TypeFor[int64](), "int64(0)"},
+		{reflect.TypeFor[float64](), "float64(0)"},
+		{reflect.TypeFor[testdata.String](), `String("")`},
+		{reflect.TypeFor[*int](), "(*int)(nil)"},
+		{reflect.TypeFor[func(int) error](), "(func(int) error)(nil)"},
+		{reflect.TypeFor[<-chan int](), "(<-chan int)(nil)"},
+		{reflect.TypeFor[[]net.Addr](), "[]net.Addr(nil)"},
+		{reflect.TypeFor[map[string]any](), "map[string]interface {}(nil)"},
+		{reflect.TypeFor[error](), "error(nil)"},
+		{reflect.TypeFor[unsafe.Pointer](), "unsafe.Pointer(nil)"},
+		{reflect.TypeFor[[2]int](), "[2]int{}"},
+		{reflect.TypeFor[testdata.Tagged](), "Tagged{}"},
+		{reflect.TypeFor[struct{ A int }](), "struct { A int }{}"},
+		{reflect.TypeFor[testdata.BTreeNode[int]](), "BTreeNode[int]{}"},
+	}
+
+	imports := codegen.NewImports(local)
+	ctx := typx.CtxPkgNamer.With(context.Background(), imports)
+
+	exprs := make([]string, 0, len(cases)*2)
+	for _, c := range cases {
+		for _, x := range []typx.Type{
+			typx.NewRType(c.typ),
+			typx.NewTType(typi.NewTTByRT(c.typ)),
+		} {
+			expr := codegen.Zero(ctx, x)
+			Expect(t, expr, Equal(c.expect))
+			exprs = append(exprs, expr)
+		}
+	}
+	Expect(t, imports.Paths(), Equal([]string{"net", "unsafe"}))
+
+	for i, typ := range check(t, imports, exprs...) {
+		// local package is checked again, compares by type literal
+		Expect(t, typ.String(), Equal(typi.NewTTByRT(cases[i/2].typ).String()))
+	}
+}
+
+type Nested struct {
+	ID    int64
+	Name  testdata.String
+	Inner struct {
+		Addr  net.Addr
+		Ports []int
+		Deep  struct {
+			OK bool
+		}
+	}
+	Node *testdata.BTreeNode[int]
+	testdata.Tagged
+	private int
+}
+
+func TestLiteral(t *testing.T) {
+	imports := codegen.NewImports(local)
+	ctx := typx.CtxPkgNamer.With(context.Background(), imports)
+
+	x := typx.NewRType(reflect.TypeFor[Nested]())
+	Expect(t, codegen.Literal(ctx, x), Equal(
+		`codegen_test.Nested{
+	ID: 0,
+	Name: "",
+	Inner: struct { Addr net.Addr; Ports []int; Deep struct { OK bool } }{
+		Addr: nil,
+		Ports: []int{},
+		Deep: struct { OK bool }{
+			OK: false,
+		},
+	},
+	Node: nil,
+	Tagged: Tagged{},
+}`,
+	))
+
+	t.Run("Local", func(t *testing.T) {
+		for _, x := range []typx.Type{
+			typx.NewRType(reflect.TypeFor[testdata.Tagged]()),
+			typx.NewTType(typi.NewTTByRT(reflect.TypeFor[testdata.Tagged]())),
+		} {
+			imports := codegen.NewImports(local)
+			ctx := typx.CtxPkgNamer.With(context.Background(), imports)
+			expr := codegen.Literal(ctx, x)
+			Expect(t, expr, Equal(`Tagged{
+	A: "",
+	B: "",
+	Namer: nil,
+	EmptyInterface: nil,
+	unexported: nil,
+}`))
+			typ := check(t, imports, expr)[0]
+			Expect(t, typ.String(), Equal(local+".Tagged"))
+		}
+	})
+
+	t.Run("NonComposite", func(t *testing.T) {
+		Expect(t, codegen.Literal(ctx, typx.NewRType(reflect.TypeFor[[]int]())), Equal("[]int{}"))
+		Expect(t, codegen.Literal(ctx, typx.NewRType(reflect.TypeFor[*int]())), Equal("(*int)(nil)"))
+		Expect(t, codegen.Literal(ctx, typx.NewRType(reflect.TypeFor[struct{}]())), Equal("struct {}{}"))
+	})
+}
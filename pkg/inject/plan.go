@@ -0,0 +1,231 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// Resolve resolves the providers of targets and their dependencies from
+// providers. a type is provided by the provider whose provided type is
+// assignable to it. if several providers match, the one provides the identical
+// type is selected, otherwise it is ambiguous. it returns error if any type has
+// no provider or providers depend on each other cyclically.
+func Resolve(providers []*Provider, targets ...typx.Type) (*Plan, error) {
+	r := &resolver{providers: providers, plan: &Plan{}, steps: make(map[*Provider]int)}
+	for _, t := range targets {
+		x, err := static(t)
+		if err != nil {
+			return nil, err
+		}
+		p, err := r.provider(x)
+		if err != nil {
+			return nil, err
+		}
+		i, err := r.resolve(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		r.plan.Targets = append(r.plan.Targets, t)
+		r.plan.Results = append(r.plan.Results, i)
+	}
+	return r.plan, nil
+}
+
+type resolver struct {
+	providers []*Provider
+	plan      *Plan
+	// steps mapping resolved provider to its step index
+	steps map[*Provider]int
+}
+
+// provider selects provider of static type t
+func (r *resolver) provider(t typx.Type) (*Provider, error) {
+	var matched []*Provider
+	for _, p := range r.providers {
+		if p.out.AssignableTo(t) {
+			matched = append(matched, p)
+		}
+	}
+	if len(matched) > 1 {
+		identical := slices.DeleteFunc(slices.Clone(matched), func(p *Provider) bool {
+			return !typx.Identical(p.out, t)
+		})
+		if len(identical) == 1 {
+			matched = identical
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no provider of %s", t)
+	case 1:
+		return matched[0], nil
+	default:
+		names := make([]string, len(matched))
+		for i, p := range matched {
+			names[i] = p.String()
+		}
+		return nil, fmt.Errorf("ambiguous providers of %s: %s", t, strings.Join(names, ", "))
+	}
+}
+
+// resolve appends steps of p and its dependencies in dependency order and
+// returns the step index of p. chain is the providers depending on p.
+func (r *resolver) resolve(p *Provider, chain []*Provider) (int, error) {
+	if i, ok := r.steps[p]; ok {
+		return i, nil
+	}
+	if i := slices.Index(chain, p); i >= 0 {
+		names := make([]string, 0, len(chain)-i+1)
+		for _, x := range append(chain[i:], p) {
+			names = append(names, x.String())
+		}
+		return 0, fmt.Errorf("dependency cycle: %s", strings.Join(names, " -> "))
+	}
+
+	chain = append(chain, p)
+	s := &Step{Provider: p, Args: make([]int, len(p.ins))}
+	for i, in := range p.ins {
+		dep, err := r.provider(in)
+		if err != nil {
+			return 0, fmt.Errorf("%w, required by parameter %d of %s", err, i, p)
+		}
+		if s.Args[i], err = r.resolve(dep, chain); err != nil {
+			return 0, err
+		}
+	}
+	r.plan.Steps = append(r.plan.Steps, s)
+	r.steps[p] = len(r.plan.Steps) - 1
+	return r.steps[p], nil
+}
+
+// Plan is the resolved steps calling providers in dependency order
+type Plan struct {
+	Steps []*Step
+	// Targets are the resolved types
+	Targets []typx.Type
+	// Results are the indices of steps providing targets
+	Results []int
+}
+
+// Step calls provider with the values provided by previous steps
+type Step struct {
+	Provider *Provider
+	// Args are the indices of steps providing arguments
+	Args []int
+}
+
+// Run calls providers by reflect and returns the values of targets. it returns
+// the first error returned by providers.
+func (p *Plan) Run() ([]reflect.Value, error) {
+	values := make([]reflect.Value, len(p.Steps))
+	for i, s := range p.Steps {
+		if !s.Provider.Value.IsValid() {
+			return nil, fmt.Errorf("provider %s has no function value", s.Provider)
+		}
+		args := make([]reflect.Value, len(s.Args))
+		for j, x := range s.Args {
+			args[j] = values[x]
+		}
+		out, err := s.Provider.adapter.Call(s.Provider.Value, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call %s: %w", s.Provider, err)
+		}
+		values[i] = out[0]
+	}
+
+	results := make([]reflect.Value, len(p.Results))
+	for i, x := range p.Results {
+		results[i] = values[x]
+	}
+	return results, nil
+}
+
+// Generate generates function named name which calls providers and returns
+// targets, eg:
+//
+//	func NewApp() (*App, error) {
+//		v0 := NewSettings()
+//		v1, err := NewMemStore(v0)
+//		if err != nil {
+//			return nil, err
+//		}
+//		v2 := NewHandler(v1)
+//		v3 := NewApp(v0, v2)
+//		return v3, nil
+//	}
+//
+// the function returns an error if any provider does. the package names of
+// providers and type literals are named by typx.CtxPkgNamer in ctx, eg:
+// *codegen.Imports.
+func (p *Plan) Generate(ctx context.Context, name string) (string, error) {
+	namer, ok := typx.CtxPkgNamer.From(ctx)
+	if !ok {
+		return "", fmt.Errorf("expect package namer in context")
+	}
+
+	failable := slices.ContainsFunc(p.Steps, func(s *Step) bool { return s.Provider.adapter.Error })
+	results := make([]string, 0, len(p.Targets)+1)
+	zeros := make([]string, 0, len(p.Targets)+1)
+	for _, t := range p.Targets {
+		results = append(results, typx.TypeLit(ctx, t.Unwrap()))
+		zeros = append(zeros, zero(ctx, t))
+	}
+	if failable {
+		results = append(results, "error")
+		zeros = append(zeros, "err")
+	}
+
+	b := strings.Builder{}
+	_, _ = fmt.Fprintf(&b, "func %s() (%s) {\n", name, strings.Join(results, ", "))
+	for i, s := range p.Steps {
+		if s.Provider.Name == "" {
+			return "", fmt.Errorf("provider %s has no function name", s.Provider)
+		}
+		fn := s.Provider.Name
+		if pkg := namer.PackageName(s.Provider.PkgPath); pkg != "" {
+			fn = pkg + "." + fn
+		}
+		args := make([]string, len(s.Args))
+		for j, x := range s.Args {
+			args[j] = fmt.Sprintf("v%d", x)
+		}
+		if s.Provider.adapter.Error {
+			_, _ = fmt.Fprintf(&b, "v%d, err := %s(%s)\n", i, fn, strings.Join(args, ", "))
+			_, _ = fmt.Fprintf(&b, "if err != nil {\nreturn %s\n}\n", strings.Join(zeros, ", "))
+		} else {
+			_, _ = fmt.Fprintf(&b, "v%d := %s(%s)\n", i, fn, strings.Join(args, ", "))
+		}
+	}
+	values := make([]string, 0, len(p.Results)+1)
+	for _, x := range p.Results {
+		values = append(values, fmt.Sprintf("v%d", x))
+	}
+	if failable {
+		values = append(values, "nil")
+	}
+	_, _ = fmt.Fprintf(&b, "return %s\n}\n", strings.Join(values, ", "))
+
+	code, err := format.Source([]byte("package x\n\n" + b.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return strings.TrimPrefix(string(code), "package x\n\n"), nil
+}
+
+// zero returns the zero value of result type t in return statement
+func zero(ctx context.Context, t typx.Type) string {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return "nil"
+	default:
+		return codegen.Zero(ctx, t)
+	}
+}
@@ -0,0 +1,181 @@
+package inject_test
+
+import (
+	"context"
+	"errors"
+	"go/types"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/codegen"
+	"github.com/xoctopus/typx/pkg/inject"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+	"github.com/xoctopus/typx/testdata/versioned.v2"
+)
+
+const path = "github.com/xoctopus/typx/testdata"
+
+func providers(t *testing.T, fns ...any) []*inject.Provider {
+	ps := make([]*inject.Provider, len(fns))
+	for i, fn := range fns {
+		var err error
+		switch x := fn.(type) {
+		case string:
+			f, e := typx.LookupFunc(path, x)
+			Expect(t, e, BeNil[error]())
+			ps[i], err = inject.FuncProvider(f)
+		default:
+			ps[i], err = inject.ValueProvider(x)
+		}
+		Expect(t, err, BeNil[error]())
+	}
+	return ps
+}
+
+func TestResolve(t *testing.T) {
+	app := typx.NewRType(reflect.TypeFor[*testdata.App]())
+	store := typx.NewRType(reflect.TypeFor[testdata.Store]())
+
+	t.Run("Run", func(t *testing.T) {
+		ps := providers(t, testdata.NewApp, testdata.NewHandler, testdata.NewMemStore, testdata.NewSettings)
+		plan, err := inject.Resolve(ps, app, store)
+		Expect(t, err, BeNil[error]())
+		Expect(t, len(plan.Steps), Equal(4))
+		Expect(t, plan.Results, Equal([]int{3, 1}))
+
+		values, err := plan.Run()
+		Expect(t, err, BeNil[error]())
+		a := values[0].Interface().(*testdata.App)
+		Expect(t, a.Addr, Equal(":80"))
+		Expect(t, a.Handler.Store, Equal[testdata.Store](values[1].Interface().(*testdata.MemStore)))
+
+		ps = providers(t, testdata.NewApp, testdata.NewHandler, testdata.NewMemStore, func() testdata.Settings {
+			return testdata.Settings{}
+		})
+		plan, err = inject.Resolve(ps, app)
+		Expect(t, err, BeNil[error]())
+		_, err = plan.Run()
+		Expect(t, err.Error(), Equal("failed to call github.com/xoctopus/typx/testdata.NewMemStore: empty dsn"))
+	})
+
+	t.Run("Generate", func(t *testing.T) {
+		// static and runtime providers are mixed
+		ps := providers(t, "NewApp", testdata.NewHandler, "NewMemStore", testdata.NewSettings)
+		plan, err := inject.Resolve(ps, app)
+		Expect(t, err, BeNil[error]())
+
+		imports := codegen.NewImports("github.com/xoctopus/typx/example")
+		code, err := plan.Generate(typx.CtxPkgNamer.With(context.Background(), imports), "NewApp")
+		Expect(t, err, BeNil[error]())
+		Expect(t, code, Equal(`func NewApp() (*testdata.App, error) {
+	v0 := testdata.NewSettings()
+	v1, err := testdata.NewMemStore(v0)
+	if err != nil {
+		return nil, err
+	}
+	v2 := testdata.NewHandler(v1)
+	v3 := testdata.NewApp(v0, v2)
+	return v3, nil
+}
+`))
+		Expect(t, imports.Paths(), Equal([]string{path}))
+
+		_, err = plan.Run()
+		Expect(t, err.Error(), Equal("provider github.com/xoctopus/typx/testdata.NewMemStore has no function value"))
+
+		imports = codegen.NewImports(path)
+		ps = providers(t, testdata.NewSettings)
+		plan, err = inject.Resolve(ps, typx.NewRType(reflect.TypeFor[testdata.Settings]()))
+		Expect(t, err, BeNil[error]())
+		code, err = plan.Generate(typx.CtxPkgNamer.With(context.Background(), imports), "settings")
+		Expect(t, err, BeNil[error]())
+		Expect(t, code, Equal("func settings() Settings {\n\tv0 := NewSettings()\n\treturn v0\n}\n"))
+
+		_, err = plan.Generate(context.Background(), "settings")
+		Expect(t, err, NotBeNil[error]())
+		ps = providers(t, func() testdata.Settings { return testdata.Settings{} })
+		plan, _ = inject.Resolve(ps, typx.NewRType(reflect.TypeFor[testdata.Settings]()))
+		_, err = plan.Generate(typx.CtxPkgNamer.With(context.Background(), imports), "settings")
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		ps := providers(t, testdata.NewApp, testdata.NewHandler, testdata.NewSettings)
+		_, err := inject.Resolve(ps, app)
+		Expect(t, err.Error(), Equal("no provider of github.com/xoctopus/typx/testdata.Store, required by parameter 0 of github.com/xoctopus/typx/testdata.NewHandler"))
+
+		ps = providers(t, testdata.NewMemStore, testdata.NewSettings, func() testdata.Store { return nil })
+		plan, err := inject.Resolve(ps, store)
+		Expect(t, err, BeNil[error]())
+		// identical provided type is selected
+		Expect(t, plan.Steps[0].Provider, Equal(ps[2]))
+
+		ps = providers(t, testdata.NewMemStore, testdata.NewSettings, func() (*testdata.MemStore, error) { return nil, errors.New("") })
+		_, err = inject.Resolve(ps, store)
+		Expect(t, err, NotBeNil[error]())
+
+		ps = providers(t, testdata.NewPing, "NewPong")
+		_, err = inject.Resolve(ps, typx.NewRType(reflect.TypeFor[*testdata.Ping]()))
+		Expect(t, err.Error(), Equal("dependency cycle: github.com/xoctopus/typx/testdata.NewPing -> github.com/xoctopus/typx/testdata.NewPong -> github.com/xoctopus/typx/testdata.NewPing"))
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	p := providers(t, testdata.NewHandler)[0]
+	Expect(t, p.String(), Equal("github.com/xoctopus/typx/testdata.NewHandler"))
+	Expect(t, p.Provides().String(), Equal("*github.com/xoctopus/typx/testdata.Handler"))
+	Expect(t, len(p.Requires()), Equal(1))
+	// static types are matched
+	Expect(t, p.Requires()[0].Unwrap().(types.Type).String(), Equal("github.com/xoctopus/typx/testdata.Store"))
+
+	for _, c := range []struct {
+		fn            any
+		pkgPath, name string
+	}{
+		{versioned.NewVersion, path + "/versioned.v2", "NewVersion"},
+		// closures and methods cannot be generated
+		{func() *versioned.Version { return nil }, "", ""},
+		{versioned.Version.String, "", ""},
+		{(*versioned.Version).String, "", ""},
+	} {
+		p, err := inject.ValueProvider(c.fn)
+		Expect(t, err, BeNil[error]())
+		Expect(t, p.PkgPath, Equal(c.pkgPath))
+		Expect(t, p.Name, Equal(c.name))
+	}
+
+	for _, fn := range []typx.Type{
+		nil,
+		typx.NewRType(reflect.TypeFor[int]()),
+		typx.NewRType(reflect.TypeFor[testdata.Func1]()),
+		typx.NewRType(reflect.TypeFor[func() (int, string)]()),
+		typx.NewRType(reflect.TypeFor[func() (int, error, error)]()),
+	} {
+		_, err := inject.NewProvider(fn)
+		Expect(t, err, NotBeNil[error]())
+	}
+
+	// declared in test function cannot be loaded from source
+	type declared struct{ Name string }
+	_, err := inject.ValueProvider(func() declared { return declared{} })
+	Expect(t, err, NotBeNil[error]())
+	_, err = inject.Resolve(nil, typx.NewRType(reflect.TypeFor[declared]()))
+	Expect(t, err, NotBeNil[error]())
+
+	_, err = inject.ValueProvider(1)
+	Expect(t, err, NotBeNil[error]())
+	_, err = inject.ValueProvider((func())(nil))
+	Expect(t, err, NotBeNil[error]())
+
+	f, err := typx.LookupFunc(path, "Max.Compute")
+	Expect(t, err, BeNil[error]())
+	_, err = inject.FuncProvider(f)
+	Expect(t, err, NotBeNil[error]())
+	f, err = typx.LookupFunc(path, "Apply")
+	Expect(t, err, BeNil[error]())
+	_, err = inject.FuncProvider(f)
+	Expect(t, err, NotBeNil[error]())
+}
@@ -0,0 +1,171 @@
+// Package inject resolves dependencies between provider functions by their
+// types. providers are matched to the required types by assignability, so a
+// provider of *T satisfies the interfaces implemented by *T. the resolved Plan
+// can be run by reflect or generated as Go code wiring the providers.
+package inject
+
+import (
+	"fmt"
+	"go/token"
+	"net/url"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// NewProvider creates provider of function type fn, which returns the provided
+// type and an optional trailing error. the non-variadic parameters are the
+// dependencies, variadic parameter receives no arguments. fn can be a static
+// or runtime type.
+func NewProvider(fn typx.Type) (*Provider, error) {
+	if fn == nil || fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("provider must be a function")
+	}
+	n := fn.NumIn()
+	if fn.IsVariadic() {
+		n--
+	}
+	if fn.NumOut() == 0 || fn.NumOut() > 2 {
+		return nil, fmt.Errorf("provider %s must return the provided type and an optional error", fn)
+	}
+
+	ins := make([]typx.Type, n)
+	for i := range ins {
+		ins[i] = fn.In(i)
+	}
+	adapter, err := typx.Adapt(fn, ins, []typx.Type{fn.Out(0)})
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider: %w", err)
+	}
+
+	p := &Provider{Type: fn, adapter: adapter, ins: make([]typx.Type, n)}
+	if p.out, err = static(fn.Out(0)); err != nil {
+		return nil, err
+	}
+	for i, in := range ins {
+		if p.ins[i], err = static(in); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// FuncProvider creates provider of package level function f, which can be
+// generated
+func FuncProvider(f *typx.Func) (*Provider, error) {
+	if f.Recv() != nil {
+		return nil, fmt.Errorf("provider %s must be a package level function", f)
+	}
+	if len(f.TypeParams()) > 0 {
+		return nil, fmt.Errorf("provider %s must not be generic", f)
+	}
+	p, err := NewProvider(f.Signature())
+	if err != nil {
+		return nil, err
+	}
+	p.PkgPath, p.Name = f.PkgPath(), f.Name()
+	return p, nil
+}
+
+// ValueProvider creates provider of function value fn, which can be run. it can
+// be generated if fn is a package level function.
+func ValueProvider(fn any) (*Provider, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return nil, fmt.Errorf("provider must be a function")
+	}
+	p, err := NewProvider(typx.NewRType(v.Type()))
+	if err != nil {
+		return nil, err
+	}
+	p.Value = v
+
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		p.PkgPath, p.Name = funcName(f.Name(), v.Type())
+	}
+	return p, nil
+}
+
+// closure matches the names of closures, eg: `pkg.Func.func1`
+var closure = regexp.MustCompile(`^func\d+$`)
+
+// funcName splits runtime function name of package level function with type t
+// into package path and name, eg: `github.com/xoctopus/typx/testdata.NewApp`.
+// the dots of the last path element are escaped by runtime, eg:
+// `gopkg.in/yaml%2ev3.Unmarshal`. it returns empty strings for closures and
+// methods, which are named as `pkg.Func.func1`, `pkg.(*T).Method` and
+// `pkg.T.Method`.
+func funcName(name string, t reflect.Type) (string, string) {
+	i := strings.LastIndex(name, ".")
+	if i <= strings.LastIndex(name, "/") {
+		return "", ""
+	}
+	pkg, fn := name[:i], name[i+1:]
+	if !token.IsIdentifier(fn) || closure.MatchString(fn) ||
+		strings.HasSuffix(pkg, ".") || strings.ContainsAny(pkg[strings.LastIndex(pkg, "/")+1:], "()[]") {
+		return "", ""
+	}
+	pkg, err := url.PathUnescape(pkg)
+	if err != nil {
+		return "", ""
+	}
+	// method expression of value receiver
+	if t.NumIn() > 0 && t.In(0).Name() != "" && t.In(0).PkgPath()+"."+t.In(0).Name() == pkg {
+		return "", ""
+	}
+	return pkg, fn
+}
+
+// Provider provides value of its first result type
+type Provider struct {
+	// Type is the function type of provider
+	Type typx.Type
+	// PkgPath and Name reference the provider function in generated code, the
+	// provider cannot be generated if Name is empty
+	PkgPath string
+	Name    string
+	// Value is the function value called by Plan.Run, the provider cannot be
+	// run if it is invalid
+	Value reflect.Value
+
+	adapter *typx.Adapter
+	// out and ins are the static types of provided type and dependencies
+	out typx.Type
+	ins []typx.Type
+}
+
+// Provides returns the provided type as static type, which is matched against
+// the required types
+func (p *Provider) Provides() typx.Type {
+	return p.out
+}
+
+// Requires returns the dependencies as static types, which are matched against
+// the provided types
+func (p *Provider) Requires() []typx.Type {
+	return p.ins
+}
+
+func (p *Provider) String() string {
+	if p.Name == "" {
+		return p.Type.String()
+	}
+	return p.PkgPath + "." + p.Name
+}
+
+// static converts t to static type, so that types from different backends can
+// be related
+func static(t typx.Type) (typx.Type, error) {
+	if r, ok := t.Unwrap().(reflect.Type); ok {
+		x, err := typi.TryNewTTByRT(r)
+		if err != nil {
+			return nil, err
+		}
+		return typx.NewTType(x), nil
+	}
+	return t, nil
+}
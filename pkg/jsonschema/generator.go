@@ -0,0 +1,185 @@
+package jsonschema
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+
+	"github.com/xoctopus/typx/internal/jsonfields"
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// Generate generates JSON Schema document of t. named types are defined in
+// `$defs` keyed by wrapped type id and referenced by `$ref`, so the document of
+// rtype and ttype of the same type are identical.
+func Generate(t typx.Type) (*Schema, error) {
+	g := NewGenerator()
+	s, err := g.Schema(t)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s, nil
+}
+
+// NewGenerator creates a Generator which references definitions by
+// `#/$defs/{id}`
+func NewGenerator() *Generator {
+	return &Generator{
+		Ref:  func(id string) string { return "#/$defs/" + id },
+		defs: make(map[string]*Schema),
+	}
+}
+
+// Generator generates schemas of types following encoding/json rules and
+// collects definitions of named types.
+type Generator struct {
+	// Ref returns `$ref` of definition by wrapped type id
+	Ref func(id string) string
+
+	defs map[string]*Schema
+}
+
+// Defs returns collected definitions keyed by wrapped type id
+func (g *Generator) Defs() map[string]*Schema {
+	return g.defs
+}
+
+// Schema returns schema of t. if t is a named type, its definition is collected
+// and a reference schema is returned.
+func (g *Generator) Schema(t typx.Type) (*Schema, error) {
+	if t.PkgPath() == "" || t.Name() == "" {
+		return g.define(t)
+	}
+
+	id := typi.Wrap(t.Unwrap())
+	if _, ok := g.defs[id]; !ok {
+		// placeholder for recursive reference
+		g.defs[id] = nil
+		s, err := g.define(t)
+		if err != nil {
+			delete(g.defs, id)
+			return nil, err
+		}
+		g.defs[id] = s
+	}
+	return &Schema{Ref: g.Ref(id)}, nil
+}
+
+func (g *Generator) define(t typx.Type) (*Schema, error) {
+	if n, ok := t.Unwrap().(*types.Named); ok && n.TypeParams().Len() > 0 && n.TypeArgs().Len() == 0 {
+		return nil, fmt.Errorf("uninstantiated generic type %s", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	}
+
+	if t.PkgPath() == "time" && t.Name() == "Time" {
+		return &Schema{Type: Types{"string"}, Format: "date-time"}, nil
+	}
+	if _, ok := t.MethodByName("MarshalJSON"); ok {
+		return &Schema{}, nil
+	}
+	if _, ok := t.MethodByName("MarshalText"); ok {
+		return &Schema{Type: Types{"string"}}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Types{"integer"}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: Types{"integer"}, Minimum: new(int64)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}, nil
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}, nil
+	case reflect.Slice:
+		// nil slice is encoded as null
+		if binary(t) {
+			return nullable(&Schema{Type: Types{"string"}, ContentEncoding: "base64"}), nil
+		}
+		items, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(&Schema{Type: Types{"array"}, Items: items}), nil
+	case reflect.Array:
+		items, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		n := t.Len()
+		return &Schema{Type: Types{"array"}, Items: items, MinItems: &n, MaxItems: &n}, nil
+	case reflect.Map:
+		s := &Schema{Type: Types{"object"}}
+		switch k := t.Key(); {
+		case k.Kind() == reflect.String:
+		case hasMethod(k, "MarshalText"):
+		case k.Kind() >= reflect.Int && k.Kind() <= reflect.Int64:
+			s.PropertyNames = &Schema{Pattern: "^-?[0-9]+$"}
+		case k.Kind() >= reflect.Uint && k.Kind() <= reflect.Uintptr:
+			s.PropertyNames = &Schema{Pattern: "^[0-9]+$"}
+		default:
+			return nil, fmt.Errorf("unsupported map key type %s", k)
+		}
+		elem, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s.AdditionalProperties = elem
+		// nil map is encoded as null
+		return nullable(s), nil
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func (g *Generator) object(t typx.Type) (*Schema, error) {
+	s := &Schema{Type: Types{"object"}}
+	for _, f := range jsonfields.Fields(t) {
+		var (
+			p   *Schema
+			err error
+		)
+		if f.Quoted {
+			p = &Schema{Type: Types{"string"}}
+			if f.Type.Kind() == reflect.Pointer {
+				p = nullable(p)
+			}
+		} else if p, err = g.Schema(f.Type); err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", f.Name, t, err)
+		}
+		s.Properties = append(s.Properties, &Property{Name: f.Name, Schema: p})
+		if !f.Omit {
+			s.Required = append(s.Required, f.Name)
+		}
+	}
+	return s, nil
+}
+
+// binary reports if slice t is encoded as base64 string
+func binary(t typx.Type) bool {
+	e := t.Elem()
+	return e.Kind() == reflect.Uint8 && !hasMethod(e, "MarshalJSON") && !hasMethod(e, "MarshalText")
+}
+
+func hasMethod(t typx.Type, name string) bool {
+	_, ok := t.MethodByName(name)
+	return ok
+}
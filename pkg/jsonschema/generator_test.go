@@ -0,0 +1,110 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/jsonschema"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+const path = "github.com/xoctopus/typx/testdata"
+
+func TestGenerate(t *testing.T) {
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+
+	tt, ok := p.Type("Article")
+	Expect(t, ok, BeTrue())
+	rt := typx.NewRType(reflect.TypeFor[testdata.Article]())
+
+	documents := make([]string, 0, 2)
+	for _, x := range []typx.Type{rt, tt} {
+		s, err := jsonschema.Generate(x)
+		Expect(t, err, BeNil[error]())
+		data, err := json.MarshalIndent(s, "", "  ")
+		Expect(t, err, BeNil[error]())
+		documents = append(documents, string(data)+"\n")
+	}
+	Expect(t, documents[0], Equal(documents[1]))
+
+	filename := "../../testdata/jsonschema/article.json"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		Expect(t, os.WriteFile(filename, []byte(documents[0]), 0o644), BeNil[error]())
+	}
+	golden, err := os.ReadFile(filename)
+	Expect(t, err, BeNil[error]())
+	Expect(t, documents[0], Equal(string(golden)))
+
+	t.Run("Properties", func(t *testing.T) {
+		g := jsonschema.NewGenerator()
+		s, err := g.Schema(rt)
+		Expect(t, err, BeNil[error]())
+		Expect(t, s.Ref, Equal("#/$defs/github_com_xoctopus_typx_testdata.Article"))
+
+		article := g.Defs()["github_com_xoctopus_typx_testdata.Article"]
+		names := make([]string, len(article.Properties))
+		for i, prop := range article.Properties {
+			names[i] = prop.Name
+		}
+		// the same keys as encoding/json
+		data, err := json.Marshal(testdata.Article{ArticleAudit: &testdata.ArticleAudit{}})
+		Expect(t, err, BeNil[error]())
+		keys := make(map[string]any)
+		Expect(t, json.Unmarshal(data, &keys), BeNil[error]())
+		Expect(t, len(names), Equal(len(keys)+3)) // omitted subtitle, Words and replies
+		for _, name := range names {
+			if name == "subtitle" || name == "Words" || name == "replies" {
+				continue
+			}
+			_, ok := keys[name]
+			Expect(t, ok, BeTrue())
+		}
+		Expect(t, names, Equal([]string{
+			"metaTitle", "author", "Title", "reviewer", "status",
+			"id", "title", "subtitle", "-", "Words", "score", "published",
+			"content", "digest", "labels", "counters", "addrs", "parent",
+			"replies", "payload", "createdAt", "updatedAt", "revisions", "extra",
+		}))
+
+		id, _ := article.Properties.Get("id")
+		Expect(t, id.Type, Equal(jsonschema.Types{"string"}))
+		score, _ := article.Properties.Get("score")
+		Expect(t, score.Type, Equal(jsonschema.Types{"string", "null"}))
+		content, _ := article.Properties.Get("content")
+		Expect(t, content.ContentEncoding, Equal("base64"))
+		// nil slices and maps are encoded as null
+		Expect(t, keys["content"], BeNil[any]())
+		Expect(t, content.Type, Equal(jsonschema.Types{"string", "null"}))
+		Expect(t, keys["labels"], BeNil[any]())
+		labels, _ := article.Properties.Get("labels")
+		Expect(t, labels.Type, Equal(jsonschema.Types{"object", "null"}))
+		parent, _ := article.Properties.Get("parent")
+		Expect(t, len(parent.AnyOf), Equal(2))
+		Expect(t, parent.AnyOf[0].Ref, Equal(s.Ref))
+		// conflicted at the same depth
+		_, ok := article.Properties.Get("Editor")
+		Expect(t, ok, BeFalse())
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		generic, ok := p.Type("TypedSlice")
+		Expect(t, ok, BeTrue())
+		for _, x := range []typx.Type{
+			typx.NewRType(reflect.TypeFor[chan int]()),
+			typx.NewRType(reflect.TypeFor[func()]()),
+			typx.NewRType(reflect.TypeFor[complex64]()),
+			typx.NewRType(reflect.TypeFor[map[[2]int]int]()),
+			typx.NewRType(reflect.TypeFor[struct{ F []func() }]()),
+			generic,
+		} {
+			_, err := jsonschema.Generate(x)
+			Expect(t, err, NotBeNil[error]())
+		}
+	})
+}
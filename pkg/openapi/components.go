@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"fmt"
+	"go/types"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/jsonschema"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+// Version is the OpenAPI version of generated document
+const Version = "3.1.0"
+
+// NewComponents creates Components which references schemas by
+// `#/components/schemas/{name}`
+func NewComponents() *Components {
+	g := jsonschema.NewGenerator()
+	g.Ref = func(id string) string { return "#/components/schemas/" + Name(id) }
+	return &Components{g: g, discriminators: make(map[string]*discriminator)}
+}
+
+// Components collects schemas of request and response types and the named
+// types referenced by them.
+type Components struct {
+	g *jsonschema.Generator
+	// discriminators keyed by wrapped type id of interface
+	discriminators map[string]*discriminator
+}
+
+type discriminator struct {
+	property string
+	mapping  map[string]typx.Type
+}
+
+// Add adds schemas of types ts
+func (c *Components) Add(ts ...typx.Type) error {
+	for _, t := range ts {
+		if _, err := c.g.Schema(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Discriminate registers implementations of named interface iface keyed by the
+// value of discriminator property. the schema of iface is described as any of
+// the implementations, or null as the zero value of interface.
+func (c *Components) Discriminate(iface typx.Type, property string, impls map[string]typx.Type) error {
+	if iface.PkgPath() == "" || iface.Name() == "" || iface.Kind() != reflect.Interface {
+		return fmt.Errorf("%s is not a named interface", iface)
+	}
+	x, err := ttype(iface)
+	if err != nil {
+		return err
+	}
+	it := x.Underlying().(*types.Interface)
+	for value, impl := range impls {
+		tt, err := ttype(impl)
+		if err != nil {
+			return err
+		}
+		if !types.Implements(tt, it) && !types.Implements(types.NewPointer(tt), it) {
+			return fmt.Errorf("%s of `%s` does not implement %s", impl, value, iface)
+		}
+		if _, err := c.g.Schema(impl); err != nil {
+			return err
+		}
+	}
+	if _, err := c.g.Schema(iface); err != nil {
+		return err
+	}
+	c.discriminators[typi.Wrap(iface.Unwrap())] = &discriminator{property: property, mapping: impls}
+	return nil
+}
+
+// ttype returns types.Type of t, reflect.Type is converted by package scanning
+func ttype(t typx.Type) (types.Type, error) {
+	if r, ok := t.Unwrap().(reflect.Type); ok {
+		return typi.TryNewTTByRT(r)
+	}
+	return t.Unwrap().(types.Type), nil
+}
+
+// Schemas returns component schemas keyed by component name, each schema has
+// an example of its zero value. nil slices are exemplified as empty arrays to
+// conform to their schemas.
+func (c *Components) Schemas() map[string]*Schema {
+	schemas := make(map[string]*Schema)
+	for id, def := range c.g.Defs() {
+		s := &Schema{Schema: def}
+		if d, ok := c.discriminators[id]; ok {
+			s.Schema = &jsonschema.Schema{}
+			s.Discriminator = &Discriminator{
+				PropertyName: d.property,
+				Mapping:      make(map[string]string),
+			}
+			for _, value := range slices.Sorted(maps.Keys(d.mapping)) {
+				impl := d.mapping[value]
+				if impl.Kind() == reflect.Pointer {
+					impl = impl.Elem()
+				}
+				ref, _ := c.g.Schema(impl)
+				s.AnyOf = append(s.AnyOf, ref)
+				s.Discriminator.Mapping[value] = ref.Ref
+			}
+			s.AnyOf = append(s.AnyOf, &jsonschema.Schema{Type: jsonschema.Types{"null"}})
+		}
+		schemas[Name(id)] = s
+	}
+	for _, s := range schemas {
+		s.Examples = []any{zero(schemas, s.Schema, make(map[string]bool))}
+	}
+	return schemas
+}
+
+// Document returns OpenAPI document with component schemas only
+func (c *Components) Document(info Info) *Document {
+	d := &Document{OpenAPI: Version, Info: info}
+	d.Components.Schemas = c.Schemas()
+	return d
+}
+
+// Name returns component name of wrapped type id, the characters not allowed
+// by component name are replaced by underscore.
+func Name(id string) string {
+	return strings.Map(func(c rune) rune {
+		if c == '.' || c == '-' || c == '_' ||
+			'0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+			return c
+		}
+		return '_'
+	}, id)
+}
+
+// zero returns json value of zero value described by s
+func zero(schemas map[string]*Schema, s *jsonschema.Schema, seen map[string]bool) any {
+	switch {
+	case s.Ref != "":
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		def, ok := schemas[name]
+		if !ok || seen[name] {
+			return nil
+		}
+		seen[name] = true
+		defer delete(seen, name)
+		return zero(schemas, def.Schema, seen)
+	case len(s.AnyOf) > 0:
+		for _, x := range s.AnyOf {
+			if slices.Equal(x.Type, jsonschema.Types{"null"}) {
+				return nil
+			}
+		}
+		return zero(schemas, s.AnyOf[0], seen)
+	case len(s.Type) == 0 || slices.Contains(s.Type, "null"):
+		return nil
+	}
+
+	switch s.Type[0] {
+	case "boolean":
+		return false
+	case "integer", "number":
+		return 0
+	case "string":
+		if s.Format == "date-time" {
+			return time.Time{}.Format(time.RFC3339)
+		}
+		return ""
+	case "array":
+		items := make([]any, 0)
+		if s.MinItems != nil {
+			for range *s.MinItems {
+				items = append(items, zero(schemas, s.Items, seen))
+			}
+		}
+		return items
+	default: // object
+		object := make(map[string]any)
+		for _, p := range s.Properties {
+			if slices.Contains(s.Required, p.Name) {
+				object[p.Name] = zero(schemas, p.Schema, seen)
+			}
+		}
+		return object
+	}
+}
@@ -0,0 +1,105 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"go/types"
+	"os"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/openapi"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+const path = "github.com/xoctopus/typx/testdata"
+
+func TestComponents(t *testing.T) {
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+
+	lookup := func(name string) typx.Type {
+		x, ok := p.Type(name)
+		Expect(t, ok, BeTrue())
+		return x
+	}
+
+	documents := make([]string, 0, 2)
+	for _, ts := range [][]typx.Type{
+		{
+			typx.NewRType(reflect.TypeFor[testdata.Drawing]()),
+			typx.NewRType(reflect.TypeFor[testdata.DrawingResponse]()),
+			typx.NewRType(reflect.TypeFor[testdata.Shape]()),
+			typx.NewRType(reflect.TypeFor[testdata.Circle]()),
+			typx.NewRType(reflect.TypeFor[*testdata.Rect]()),
+		},
+		{
+			lookup("Drawing"),
+			lookup("DrawingResponse"),
+			lookup("Shape"),
+			lookup("Circle"),
+			typx.NewTType(types.NewPointer(lookup("Rect").Unwrap().(types.Type))),
+		},
+	} {
+		c := openapi.NewComponents()
+		Expect(t, c.Add(ts[0], ts[1]), BeNil[error]())
+		err := c.Discriminate(ts[2], "kind", map[string]typx.Type{
+			"circle": ts[3],
+			"rect":   ts[4],
+		})
+		Expect(t, err, BeNil[error]())
+
+		data, err := json.MarshalIndent(c.Document(openapi.Info{Title: "drawing", Version: "v1"}), "", "  ")
+		Expect(t, err, BeNil[error]())
+		documents = append(documents, string(data)+"\n")
+	}
+	Expect(t, documents[0], Equal(documents[1]))
+
+	filename := "../../testdata/openapi/components.json"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		Expect(t, os.WriteFile(filename, []byte(documents[0]), 0o644), BeNil[error]())
+	}
+	golden, err := os.ReadFile(filename)
+	Expect(t, err, BeNil[error]())
+	Expect(t, documents[0], Equal(string(golden)))
+
+	t.Run("Examples", func(t *testing.T) {
+		c := openapi.NewComponents()
+		Expect(t, c.Add(typx.NewRType(reflect.TypeFor[testdata.Circle]())), BeNil[error]())
+		schemas := c.Schemas()
+		s := schemas[openapi.Name("github_com_xoctopus_typx_testdata.Circle")]
+		Expect(t, s, NotEqual[*openapi.Schema](nil))
+
+		data, err := json.Marshal(s.Examples[0])
+		Expect(t, err, BeNil[error]())
+		expect, err := json.Marshal(testdata.Circle{})
+		Expect(t, err, BeNil[error]())
+		Expect(t, string(data), Equal(string(expect)))
+	})
+
+	t.Run("InvalidDiscriminator", func(t *testing.T) {
+		c := openapi.NewComponents()
+		// declared in test function cannot be loaded from source
+		type declared struct{ Name string }
+		for _, x := range []struct {
+			iface typx.Type
+			impl  typx.Type
+		}{
+			{typx.NewRType(reflect.TypeFor[testdata.Shape]()), typx.NewRType(reflect.TypeFor[declared]())},
+			{typx.NewRType(reflect.TypeFor[testdata.Circle]()), typx.NewRType(reflect.TypeFor[testdata.Circle]())},
+			{typx.NewRType(reflect.TypeFor[any]()), typx.NewRType(reflect.TypeFor[testdata.Circle]())},
+			{typx.NewRType(reflect.TypeFor[testdata.Shape]()), typx.NewRType(reflect.TypeFor[testdata.Drawing]())},
+		} {
+			err := c.Discriminate(x.iface, "kind", map[string]typx.Type{"x": x.impl})
+			Expect(t, err, NotBeNil[error]())
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		c := openapi.NewComponents()
+		err := c.Add(typx.NewRType(reflect.TypeFor[chan int]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+}
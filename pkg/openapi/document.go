@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"github.com/xoctopus/typx/pkg/jsonschema"
+)
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string `json:"openapi"`
+	Info       Info   `json:"info"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Info is the metadata of api
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Schema is an OpenAPI schema object, which is a superset of JSON Schema
+type Schema struct {
+	*jsonschema.Schema
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+	Examples      []any          `json:"examples,omitempty"`
+}
+
+// Discriminator decides which schema the data is by the value of property
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
@@ -0,0 +1,255 @@
+package typx
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// CallCompatible reports why function type fn cannot be called with arguments
+// of types args as `fn(args...)` is compiled, it returns nil if compatible. nil
+// element of args denotes untyped nil, which is assignable to pointer, slice,
+// map, channel, function and interface. the arguments of variadic parameter
+// are passed individually as `Func3("x", "y", 1, 2)`. fn and args can be from
+// different backends.
+func CallCompatible(fn Type, args []Type) error {
+	_, err := params(fn, args)
+	return err
+}
+
+// params returns the parameter types receiving args
+func params(fn Type, args []Type) ([]Type, error) {
+	if fn == nil || fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%s is not a function", typeName(fn))
+	}
+	if g, _ := uninstantiated(fn); g != nil {
+		return nil, fmt.Errorf("%s is uninstantiated", fn)
+	}
+
+	n := fn.NumIn()
+	if fn.IsVariadic() {
+		n--
+	}
+	if len(args) < n {
+		return nil, fmt.Errorf("not enough arguments in call to %s, have (%s), want %s", fn, typeList(args), signatureIn(fn))
+	}
+	if len(args) > n && !fn.IsVariadic() {
+		return nil, fmt.Errorf("too many arguments in call to %s, have (%s), want %s", fn, typeList(args), signatureIn(fn))
+	}
+
+	ps := make([]Type, len(args))
+	for i, arg := range args {
+		if i < n {
+			ps[i] = fn.In(i)
+		} else {
+			ps[i] = fn.In(n).Elem()
+		}
+		ok, err := assignable(arg, ps[i])
+		if err != nil {
+			return nil, fmt.Errorf("cannot check argument %d to %s: %w", i, fn, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot use %s as %s in argument %d to %s", typeName(arg), ps[i], i, fn)
+		}
+	}
+	return ps, nil
+}
+
+// assignable reports if value of type x is assignable to type y, nil x denotes
+// untyped nil. the types from different backends are bridged to go/types, it
+// returns error if the reflect.Type cannot be loaded.
+func assignable(x, y Type) (bool, error) {
+	if x == nil {
+		return nilable(y.Kind()), nil
+	}
+	if rx, ok := x.Unwrap().(reflect.Type); ok {
+		if ry, ok := y.Unwrap().(reflect.Type); ok {
+			return rx.AssignableTo(ry), nil
+		}
+	}
+	if Identical(x, y) {
+		return true, nil
+	}
+	tx, err := bridge(x)
+	if err != nil {
+		return false, err
+	}
+	ty, err := bridge(y)
+	if err != nil {
+		return false, err
+	}
+	return types.AssignableTo(tx, ty), nil
+}
+
+// nilable reports if untyped nil is assignable to the type of kind k
+func nilable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func,
+		reflect.Interface, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+func typeName(t Type) string {
+	if t == nil {
+		return "nil"
+	}
+	return t.String()
+}
+
+func typeList(ts []Type) string {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = typeName(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// signatureIn returns parameter list of function type fn, eg: `(string, ...int)`
+func signatureIn(fn Type) string {
+	names := make([]string, fn.NumIn())
+	for i := range names {
+		if i == fn.NumIn()-1 && fn.IsVariadic() {
+			names[i] = "..." + fn.In(i).Elem().String()
+		} else {
+			names[i] = fn.In(i).String()
+		}
+	}
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+// Adapt checks function type fn can be called with arguments of types args,
+// see CallCompatible, and its leading results are assignable to results. fn
+// may return an extra trailing error, which is returned by Adapter.Call.
+func Adapt(fn Type, args []Type, results []Type) (*Adapter, error) {
+	ps, err := params(fn, args)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Adapter{Params: ps, Results: make([]Type, len(results))}
+	switch n := fn.NumOut(); {
+	case n == len(results)+1 && isError(fn.Out(n-1)):
+		a.Error = true
+	case n != len(results):
+		return nil, fmt.Errorf("%s returns %d results, want (%s)", fn, n, typeList(results))
+	}
+	for i, r := range results {
+		out := fn.Out(i)
+		ok, err := assignable(out, r)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check result %d of %s: %w", i, fn, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot use %s as %s in result %d of %s", out, r, i, fn)
+		}
+		a.Results[i] = out
+	}
+	return a, nil
+}
+
+func isError(t Type) bool {
+	return t.Kind() == reflect.Interface && t.PkgPath() == "" && t.Name() == "error"
+}
+
+// Adapter calls function with the checked argument and result types
+type Adapter struct {
+	// Params are the parameter types receiving arguments, the arguments of
+	// variadic parameter receive its element type
+	Params []Type
+	// Results are the result types of function assigned to the wanted results
+	Results []Type
+	// Error reports function returns an extra trailing error
+	Error bool
+}
+
+// Call calls fn, a function value of adapted type, with args by reflect. the
+// invalid reflect.Value in args denotes untyped nil. it returns the results
+// without the trailing error, which is returned as err if not nil. it returns
+// error if type of fn or args mismatches the adapter.
+func (a *Adapter) Call(fn reflect.Value, args ...reflect.Value) (results []reflect.Value, err error) {
+	if fn.Kind() != reflect.Func || fn.IsNil() {
+		return nil, errors.New("invalid function value")
+	}
+	if len(args) != len(a.Params) {
+		return nil, fmt.Errorf("adapted %d arguments, but got %d", len(a.Params), len(args))
+	}
+	ft := fn.Type()
+	if err = a.check(ft); err != nil {
+		return nil, err
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		p := ft.In(min(i, ft.NumIn()-1))
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			p = p.Elem()
+		}
+		switch {
+		case !arg.IsValid() && nilable(p.Kind()):
+			in[i] = reflect.Zero(p)
+		case arg.IsValid() && arg.Type().AssignableTo(p):
+			in[i] = arg
+		default:
+			return nil, fmt.Errorf("cannot use %s as %s in argument %d to %s", value(arg), p, i, ft)
+		}
+	}
+
+	out := fn.Call(in)
+	if a.Error {
+		if last := out[len(out)-1]; !last.IsNil() {
+			err = last.Interface().(error)
+		}
+		out = out[:len(out)-1]
+	}
+	return out, err
+}
+
+// check checks function type ft has the adapted parameters and results
+func (a *Adapter) check(ft reflect.Type) error {
+	n := ft.NumIn()
+	if ft.IsVariadic() {
+		n--
+	}
+	if len(a.Params) < n || len(a.Params) > n && !ft.IsVariadic() {
+		return fmt.Errorf("%s has %d parameters, but adapted %d arguments", ft, ft.NumIn(), len(a.Params))
+	}
+	for i, p := range a.Params {
+		x := NewRType(ft.In(min(i, n)))
+		if i >= n {
+			x = x.Elem()
+		}
+		if !Identical(x, p) {
+			return fmt.Errorf("cannot use %s as adapted %s in parameter %d of %s", x, p, i, ft)
+		}
+	}
+
+	outs := len(a.Results)
+	if a.Error {
+		outs++
+	}
+	if ft.NumOut() != outs {
+		return fmt.Errorf("%s returns %d results, but adapted %d", ft, ft.NumOut(), outs)
+	}
+	for i, r := range a.Results {
+		if x := NewRType(ft.Out(i)); !Identical(x, r) {
+			return fmt.Errorf("cannot use %s as adapted %s in result %d of %s", x, r, i, ft)
+		}
+	}
+	if a.Error && !isError(NewRType(ft.Out(outs-1))) {
+		return fmt.Errorf("the last result of %s is not error", ft)
+	}
+	return nil
+}
+
+// value returns type name of reflect value v, invalid v denotes untyped nil
+func value(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
@@ -0,0 +1,197 @@
+package typx_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestCallCompatible(t *testing.T) {
+	backends := func(r reflect.Type) []typx.Type {
+		return []typx.Type{typx.NewRType(r), typx.NewTType(typi.NewTTByRT(r))}
+	}
+	var (
+		str      = typx.NewRType(reflect.TypeFor[string]())
+		integer  = typx.NewRType(reflect.TypeFor[int]())
+		named    = typx.NewTType(typi.NewTTByRT(reflect.TypeFor[testdata.String]()))
+		stringer = typx.NewRType(reflect.TypeFor[*testdata.StringerL1]())
+	)
+
+	for _, fn := range backends(reflect.TypeFor[testdata.Func3]()) {
+		for _, args := range [][]typx.Type{
+			{str, str},
+			{str, str, integer},
+			{str, str, integer, integer},
+			{typx.NewTType(typi.NewTTByRT(reflect.TypeFor[string]())), str, integer},
+		} {
+			Expect(t, typx.CallCompatible(fn, args), BeNil[error]())
+		}
+
+		err := typx.CallCompatible(fn, []typx.Type{str})
+		Expect(t, err.Error(), Equal("not enough arguments in call to github.com/xoctopus/typx/testdata.Func3, have (string), want (string, string, ...int)"))
+		err = typx.CallCompatible(fn, []typx.Type{str, str, str})
+		Expect(t, err.Error(), Equal("cannot use string as int in argument 2 to github.com/xoctopus/typx/testdata.Func3"))
+		err = typx.CallCompatible(fn, []typx.Type{str, named})
+		Expect(t, err, NotBeNil[error]())
+		err = typx.CallCompatible(fn, []typx.Type{str, str, nil})
+		Expect(t, err.Error(), Equal("cannot use nil as int in argument 2 to github.com/xoctopus/typx/testdata.Func3"))
+	}
+
+	for _, fn := range backends(reflect.TypeFor[testdata.Curry]()) {
+		Expect(t, typx.CallCompatible(fn, []typx.Type{named, stringer, nil}), BeNil[error]())
+		// string is not assignable to named String
+		Expect(t, typx.CallCompatible(fn, []typx.Type{str}), NotBeNil[error]())
+		// StringerL1 has pointer receiver
+		x := typx.NewRType(reflect.TypeFor[testdata.StringerL1]())
+		Expect(t, typx.CallCompatible(fn, []typx.Type{named, x}), NotBeNil[error]())
+	}
+
+	for _, fn := range backends(reflect.TypeFor[func(*int, []string, any)]()) {
+		Expect(t, typx.CallCompatible(fn, []typx.Type{nil, nil, nil}), BeNil[error]())
+		err := typx.CallCompatible(fn, []typx.Type{nil, nil, nil, nil})
+		Expect(t, err.Error(), Equal("too many arguments in call to func(*int, []string, interface {}), have (nil, nil, nil, nil), want (*int, []string, interface {})"))
+	}
+
+	p, err := typx.Load("github.com/xoctopus/typx/testdata")
+	Expect(t, err, BeNil[error]())
+	apply, _ := p.Func("Apply")
+	for _, fn := range []typx.Type{nil, str, apply.Signature()} {
+		Expect(t, typx.CallCompatible(fn, nil), NotBeNil[error]())
+	}
+
+	t.Run("Unloadable", func(t *testing.T) {
+		// declared in function scope cannot be bridged to go/types
+		type declared struct{ Name string }
+		arg := typx.NewRType(reflect.TypeFor[declared]())
+		fn := typx.NewTType(typi.NewTTByRT(reflect.TypeFor[func(fmt.Stringer)]()))
+		Expect(t, typx.CallCompatible(fn, []typx.Type{arg}), NotBeNil[error]())
+		_, err := typx.Adapt(fn, []typx.Type{arg}, nil)
+		Expect(t, err, NotBeNil[error]())
+
+		// compared by reflect without bridging
+		fn = typx.NewRType(reflect.TypeFor[func(any)]())
+		Expect(t, typx.CallCompatible(fn, []typx.Type{arg}), BeNil[error]())
+	})
+}
+
+func TestAdapt(t *testing.T) {
+	var (
+		str     = typx.NewRType(reflect.TypeFor[string]())
+		integer = typx.NewRType(reflect.TypeFor[int]())
+		boolean = typx.NewRType(reflect.TypeFor[testdata.Boolean]())
+		err     = typx.NewRType(reflect.TypeFor[error]())
+	)
+
+	fn := typx.NewRType(reflect.TypeFor[testdata.Func3]())
+	f := testdata.Func3(func(x, y string, z ...int) (testdata.Boolean, error) {
+		if len(z) == 0 {
+			return false, errors.New("empty")
+		}
+		return testdata.Boolean(x+y == strconv.Itoa(z[0])), nil
+	})
+
+	t.Run("TrailingError", func(t *testing.T) {
+		a, e := typx.Adapt(fn, []typx.Type{str, str, integer}, []typx.Type{boolean})
+		Expect(t, e, BeNil[error]())
+		Expect(t, a.Error, BeTrue())
+		Expect(t, len(a.Params), Equal(3))
+		Expect(t, a.Params[2].String(), Equal("int"))
+		Expect(t, a.Results[0].String(), Equal("github.com/xoctopus/typx/testdata.Boolean"))
+
+		results, e := a.Call(reflect.ValueOf(f), reflect.ValueOf("1"), reflect.ValueOf("2"), reflect.ValueOf(12))
+		Expect(t, e, BeNil[error]())
+		Expect(t, results[0].Bool(), BeTrue())
+	})
+
+	t.Run("Results", func(t *testing.T) {
+		a, e := typx.Adapt(fn, []typx.Type{str, str}, []typx.Type{boolean, err})
+		Expect(t, e, BeNil[error]())
+		Expect(t, a.Error, BeFalse())
+		results, e := a.Call(reflect.ValueOf(f), reflect.ValueOf("1"), reflect.ValueOf("2"))
+		Expect(t, e, BeNil[error]())
+		Expect(t, results[1].Interface().(error).Error(), Equal("empty"))
+
+		a, _ = typx.Adapt(fn, []typx.Type{str, str}, []typx.Type{boolean})
+		_, e = a.Call(reflect.ValueOf(f), reflect.ValueOf("1"), reflect.ValueOf("2"))
+		Expect(t, e.Error(), Equal("empty"))
+	})
+
+	t.Run("UntypedNil", func(t *testing.T) {
+		curry := typx.NewRType(reflect.TypeFor[testdata.Curry]())
+		named := typx.NewRType(reflect.TypeFor[testdata.String]())
+		a, e := typx.Adapt(curry, []typx.Type{named, nil}, []typx.Type{typx.NewRType(reflect.TypeFor[func() string]())})
+		Expect(t, e, BeNil[error]())
+
+		c := testdata.Curry(func(x testdata.String, y ...fmt.Stringer) func() string {
+			return func() string { return fmt.Sprint(string(x), y) }
+		})
+		results, e := a.Call(reflect.ValueOf(c), reflect.ValueOf(testdata.String("x")), reflect.Value{})
+		Expect(t, e, BeNil[error]())
+		Expect(t, results[0].Interface().(func() string)(), Equal("x[<nil>]"))
+
+		a, e = typx.Adapt(typx.NewRType(reflect.TypeFor[func(*int) bool]()), []typx.Type{nil}, []typx.Type{typx.NewRType(reflect.TypeFor[bool]())})
+		Expect(t, e, BeNil[error]())
+		results, e = a.Call(reflect.ValueOf(func(x *int) bool { return x == nil }), reflect.Value{})
+		Expect(t, e, BeNil[error]())
+		Expect(t, results[0].Bool(), BeTrue())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, e := typx.Adapt(fn, []typx.Type{str}, nil)
+		Expect(t, e, NotBeNil[error]())
+		_, e = typx.Adapt(fn, []typx.Type{str, str}, nil)
+		Expect(t, e.Error(), Equal("github.com/xoctopus/typx/testdata.Func3 returns 2 results, want ()"))
+		_, e = typx.Adapt(fn, []typx.Type{str, str}, []typx.Type{typx.NewRType(reflect.TypeFor[bool]())})
+		Expect(t, e.Error(), Equal("cannot use github.com/xoctopus/typx/testdata.Boolean as bool in result 0 of github.com/xoctopus/typx/testdata.Func3"))
+
+		a, _ := typx.Adapt(fn, []typx.Type{str, str}, []typx.Type{boolean})
+		_, e = a.Call(reflect.ValueOf(1))
+		Expect(t, e, NotBeNil[error]())
+		_, e = a.Call(reflect.ValueOf(f), reflect.ValueOf("1"))
+		Expect(t, e, NotBeNil[error]())
+		_, e = a.Call(reflect.ValueOf(f), reflect.ValueOf(1), reflect.ValueOf("2"))
+		Expect(t, e.Error(), Equal("cannot use int as string in argument 0 to testdata.Func3"))
+		_, e = a.Call(reflect.ValueOf(f), reflect.Value{}, reflect.ValueOf("2"))
+		Expect(t, e.Error(), Equal("cannot use nil as string in argument 0 to testdata.Func3"))
+	})
+
+	t.Run("Mismatched", func(t *testing.T) {
+		a, _ := typx.Adapt(fn, []typx.Type{str, str, integer}, []typx.Type{boolean})
+		for _, c := range []struct {
+			fn  any
+			err string
+		}{
+			{
+				func(string, string) (testdata.Boolean, error) { return false, nil },
+				"func(string, string) (testdata.Boolean, error) has 2 parameters, but adapted 3 arguments",
+			},
+			{
+				func(string, int, ...int) (testdata.Boolean, error) { return false, nil },
+				"cannot use int as adapted string in parameter 1 of func(string, int, ...int) (testdata.Boolean, error)",
+			},
+			{
+				func(string, string, ...int) {},
+				"func(string, string, ...int) returns 0 results, but adapted 2",
+			},
+			{
+				func(string, string, ...int) (bool, error) { return false, nil },
+				"cannot use bool as adapted github.com/xoctopus/typx/testdata.Boolean in result 0 of func(string, string, ...int) (bool, error)",
+			},
+			{
+				func(string, string, ...int) (testdata.Boolean, string) { return false, "" },
+				"the last result of func(string, string, ...int) (testdata.Boolean, string) is not error",
+			},
+		} {
+			_, e := a.Call(reflect.ValueOf(c.fn), reflect.ValueOf("1"), reflect.ValueOf("2"), reflect.ValueOf(12))
+			Expect(t, e.Error(), Equal(c.err))
+		}
+	})
+}
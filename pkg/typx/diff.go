@@ -0,0 +1,317 @@
+package typx
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind describes the kind of api change
+type ChangeKind string
+
+const (
+	ChangeAdded      ChangeKind = "added"
+	ChangeRemoved    ChangeKind = "removed"
+	ChangeRetyped    ChangeKind = "retyped"
+	ChangeTag        ChangeKind = "tag"
+	ChangeComparable ChangeKind = "comparable"
+)
+
+// Change is an api change between two versions of a type
+type Change struct {
+	// Path of changed member from root type, eg: `Field(A).Field(B)`. empty path
+	// means the root type itself.
+	Path     string
+	Kind     ChangeKind
+	Old      string
+	New      string
+	Breaking bool
+}
+
+func (c *Change) String() string {
+	b := strings.Builder{}
+	if c.Breaking {
+		b.WriteString("breaking: ")
+	} else {
+		b.WriteString("compatible: ")
+	}
+	if c.Path != "" {
+		b.WriteString(c.Path + ": ")
+	}
+	b.WriteString(string(c.Kind))
+	switch {
+	case c.Old != "" && c.New != "":
+		_, _ = fmt.Fprintf(&b, " %s => %s", c.Old, c.New)
+	case c.Old != "":
+		b.WriteString(" " + c.Old)
+	case c.New != "":
+		b.WriteString(" " + c.New)
+	}
+	return b.String()
+}
+
+type Changes []*Change
+
+// Breaking reports if any change is breaking
+func (cs Changes) Breaking() bool {
+	return slices.ContainsFunc(cs, func(c *Change) bool { return c.Breaking })
+}
+
+func (cs Changes) String() string {
+	lines := make([]string, len(cs))
+	for i, c := range cs {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff reports api changes from old to new version of a type, in the spirit of
+// apidiff: removing or retyping exported fields and methods, renaming wire
+// names in struct tags, adding methods to interface and losing comparability
+// are breaking; adding fields and methods to struct and changing tag options
+// are compatible. old can be loaded from another version of package, the type
+// names declared in old package are compared as declared in new package.
+//
+// fields of struct types, including embedded ones, are compared recursively.
+// pointers are not followed, so `Field(A)` of type `*T` only reports retyping.
+func Diff(old, new Type) Changes {
+	d := &differ{}
+	if old.PkgPath() != "" && new.PkgPath() != "" {
+		d.renames = append(d.renames, old.PkgPath()+".", new.PkgPath()+".")
+		if old.Name() != "" && new.Name() != "" {
+			d.renames = append(d.renames, old.String(), new.String())
+		}
+	}
+	d.diff("", old, new)
+	return d.changes
+}
+
+type differ struct {
+	renames []string
+	changes Changes
+}
+
+func (d *differ) add(path string, kind ChangeKind, old, new string, breaking bool) {
+	d.changes = append(d.changes, &Change{
+		Path: path, Kind: kind, Old: old, New: new, Breaking: breaking,
+	})
+}
+
+// literal returns type literal of old type as declared in new package
+func (d *differ) literal(t Type) string {
+	return d.rename(t.String())
+}
+
+// rename replaces type names declared in old package in s
+func (d *differ) rename(s string) string {
+	// replace the root type name first
+	for i := len(d.renames) - 2; i >= 0; i -= 2 {
+		from, to := d.renames[i], d.renames[i+1]
+		b := strings.Builder{}
+		for {
+			idx := strings.Index(s, from)
+			if idx < 0 {
+				break
+			}
+			end := idx + len(from)
+			b.WriteString(s[:idx])
+			if end < len(s) && identifier(from[len(from)-1]) && identifier(s[end]) {
+				// prefix of another identifier
+				b.WriteString(from)
+			} else {
+				b.WriteString(to)
+			}
+			s = s[end:]
+		}
+		b.WriteString(s)
+		s = b.String()
+	}
+	return s
+}
+
+func identifier(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func (d *differ) diff(path string, old, new Type) {
+	if old.Kind() != new.Kind() {
+		d.add(path, ChangeRetyped, d.literal(old), new.String(), true)
+		return
+	}
+	if old.Comparable() && !new.Comparable() {
+		d.add(path, ChangeComparable, "", "", true)
+	}
+
+	switch new.Kind() {
+	case reflect.Struct:
+		d.fields(path, old, new)
+	case reflect.Interface:
+		d.methods(path, old, new, true)
+		return
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Pointer, reflect.Slice, reflect.Func:
+		if d.underlying(old) != underlying(new) {
+			d.add(path, ChangeRetyped, d.underlying(old), underlying(new), true)
+		}
+	}
+	if old.Name() != "" && new.Name() != "" {
+		d.methods(path, old, new, false)
+	}
+}
+
+// underlying returns the underlying literal of non-struct composite type
+func underlying(t Type) string {
+	switch t.Kind() {
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), t.Elem())
+	case reflect.Chan:
+		return fmt.Sprintf("%s %s", t.ChanDir(), t.Elem())
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", t.Key(), t.Elem())
+	case reflect.Pointer:
+		return "*" + t.Elem().String()
+	case reflect.Slice:
+		return "[]" + t.Elem().String()
+	default: // reflect.Func
+		ins := make([]string, t.NumIn())
+		for i := range ins {
+			ins[i] = t.In(i).String()
+		}
+		if t.IsVariadic() {
+			ins[len(ins)-1] = "..." + strings.TrimPrefix(ins[len(ins)-1], "[]")
+		}
+		outs := make([]string, t.NumOut())
+		for i := range outs {
+			outs[i] = t.Out(i).String()
+		}
+		return fmt.Sprintf("func(%s) (%s)", strings.Join(ins, ", "), strings.Join(outs, ", "))
+	}
+}
+
+func (d *differ) underlying(t Type) string {
+	return d.rename(underlying(t))
+}
+
+func (d *differ) fields(path string, old, new Type) {
+	for i := range old.NumField() {
+		f := old.Field(i)
+		if f.PkgPath() != "" {
+			continue
+		}
+		p := join(path, "Field("+f.Name()+")")
+		x, ok := field(new, f.Name())
+		if !ok {
+			d.add(p, ChangeRemoved, d.literal(f.Type()), "", true)
+			continue
+		}
+		ot, nt := f.Type(), x.Type()
+		if d.literal(ot) != nt.String() {
+			if ot.Name() == "" && nt.Name() == "" && ot.Kind() == reflect.Struct && nt.Kind() == reflect.Struct {
+				d.diff(p, ot, nt)
+			} else {
+				d.add(p, ChangeRetyped, d.literal(ot), nt.String(), true)
+			}
+		} else if ot.Name() != "" && ot.Kind() == reflect.Struct && nt.Kind() == reflect.Struct {
+			// the same named struct may differ between versions of package
+			d.diff(p, ot, nt)
+		}
+		d.tags(p, f, x)
+	}
+	for i := range new.NumField() {
+		f := new.Field(i)
+		if _, ok := field(old, f.Name()); !ok && f.PkgPath() == "" {
+			d.add(join(path, "Field("+f.Name()+")"), ChangeAdded, "", f.Type().String(), false)
+		}
+	}
+}
+
+// field returns direct field by name
+func field(t Type, name string) (StructField, bool) {
+	for i := range t.NumField() {
+		if f := t.Field(i); f.Name() == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// tags compares struct tags by key. changing wire name of a tag key is
+// breaking, changing options of tag key is compatible.
+func (d *differ) tags(path string, old, new StructField) {
+	keys := tagKeys(old.Tag())
+	for _, key := range tagKeys(new.Tag()) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		ov, _ := old.Tag().Lookup(key)
+		nv, _ := new.Tag().Lookup(key)
+		if ov == nv {
+			continue
+		}
+		oname, _, _ := strings.Cut(ov, ",")
+		nname, _, _ := strings.Cut(nv, ",")
+		if oname == "" {
+			oname = old.Name()
+		}
+		if nname == "" {
+			nname = new.Name()
+		}
+		d.add(
+			join(path, "Tag("+key+")"), ChangeTag,
+			strconv.Quote(ov), strconv.Quote(nv),
+			oname != nname,
+		)
+	}
+}
+
+// tagKeys returns keys of conventional struct tag in order
+func tagKeys(tag reflect.StructTag) []string {
+	keys := make([]string, 0)
+	s := string(tag)
+	for {
+		s = strings.TrimLeft(s, " ")
+		i := strings.Index(s, ":")
+		if i <= 0 {
+			return keys
+		}
+		quoted, err := strconv.QuotedPrefix(s[i+1:])
+		if err != nil {
+			return keys
+		}
+		keys = append(keys, s[:i])
+		s = s[i+1+len(quoted):]
+	}
+}
+
+func (d *differ) methods(path string, old, new Type, iface bool) {
+	for i := range old.NumMethod() {
+		m := old.Method(i)
+		p := join(path, "Method("+m.Name()+")")
+		x, ok := new.MethodByName(m.Name())
+		if !ok {
+			d.add(p, ChangeRemoved, d.literal(m.Type()), "", true)
+			continue
+		}
+		if ot, nt := d.literal(m.Type()), x.Type().String(); ot != nt {
+			d.add(p, ChangeRetyped, ot, nt, true)
+		}
+	}
+	for i := range new.NumMethod() {
+		m := new.Method(i)
+		if _, ok := old.MethodByName(m.Name()); !ok {
+			// adding method to interface breaks its implementations
+			d.add(join(path, "Method("+m.Name()+")"), ChangeAdded, "", m.Type().String(), iface)
+		}
+	}
+}
+
+func join(path, step string) string {
+	if path == "" {
+		return step
+	}
+	return path + "." + step
+}
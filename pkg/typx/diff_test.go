@@ -0,0 +1,187 @@
+package typx_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+type WireV1 struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Removed bool   `json:"removed"`
+	Retyped int    `json:"retyped"`
+	Nested  struct {
+		A int `json:"a"`
+	} `json:"nested"`
+	Self    *WireV1
+	private int
+}
+
+func (WireV1) Version() int { return 1 }
+
+func (WireV1) Deprecated() {}
+
+type WireV2 struct {
+	ID      int    `json:"id,omitempty"`
+	Name    string `json:"fullName"`
+	Retyped string `json:"retyped"`
+	Nested  struct {
+		A int `json:"a" xml:"a"`
+		B int `json:"b"`
+	} `json:"nested"`
+	Self    *WireV2
+	Added   []string `json:"added"`
+	changed string
+}
+
+func (WireV2) Version() string { return "2" }
+
+func (WireV2) Validate() error { return nil }
+
+func TestDiff(t *testing.T) {
+	recv := "github.com/xoctopus/typx/pkg/typx_test.WireV2"
+	both := func(r reflect.Type) []typx.Type {
+		return []typx.Type{typx.NewRType(r), typx.NewTType(typi.NewTTByRT(r))}
+	}
+	lines := func(changes typx.Changes) []string {
+		s := make([]string, len(changes))
+		for i, c := range changes {
+			s[i] = c.String()
+		}
+		return s
+	}
+
+	for _, c := range []struct {
+		name     string
+		old, new reflect.Type
+		changes  []string
+	}{
+		{
+			"Identical",
+			reflect.TypeFor[WireV1](),
+			reflect.TypeFor[WireV1](),
+			[]string{},
+		},
+		{
+			"Struct",
+			reflect.TypeFor[WireV1](),
+			reflect.TypeFor[WireV2](),
+			[]string{
+				"breaking: comparable",
+				`compatible: Field(ID).Tag(json): tag "id" => "id,omitempty"`,
+				`breaking: Field(Name).Tag(json): tag "name" => "fullName"`,
+				"breaking: Field(Removed): removed bool",
+				"breaking: Field(Retyped): retyped int => string",
+				`breaking: Field(Nested).Field(A).Tag(xml): tag "" => "a"`,
+				"compatible: Field(Nested).Field(B): added int",
+				"compatible: Field(Added): added []string",
+				// the receiver of old version is renamed as declared in new version
+				"breaking: Method(Deprecated): removed func(" + recv + ")",
+				"breaking: Method(Version): retyped func(" + recv + ") int => func(" + recv + ") string",
+				"compatible: Method(Validate): added func(" + recv + ") error",
+			},
+		},
+		{
+			"Kind",
+			reflect.TypeFor[WireV1](),
+			reflect.TypeFor[int](),
+			[]string{"breaking: retyped github.com/xoctopus/typx/pkg/typx_test.WireV1 => int"},
+		},
+		{
+			"Comparable",
+			reflect.TypeFor[struct{ A int }](),
+			reflect.TypeFor[struct{ A []int }](),
+			[]string{"breaking: comparable", "breaking: Field(A): retyped int => []int"},
+		},
+		{
+			"Interface",
+			reflect.TypeFor[interface {
+				A()
+				B(int)
+			}](),
+			reflect.TypeFor[interface {
+				B(string)
+				C()
+			}](),
+			[]string{
+				"breaking: Method(A): removed func()",
+				"breaking: Method(B): retyped func(int) => func(string)",
+				// adding method to interface breaks its implementations
+				"breaking: Method(C): added func()",
+			},
+		},
+		{
+			"Composite",
+			reflect.TypeFor[map[string][]int](),
+			reflect.TypeFor[map[string][]int64](),
+			[]string{"breaking: retyped map[string][]int => map[string][]int64"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			for _, old := range both(c.old) {
+				for _, new := range both(c.new) {
+					changes := typx.Diff(old, new)
+					Expect(t, lines(changes), Equal(c.changes))
+					Expect(t, changes.Breaking(), Equal(len(c.changes) > 0))
+				}
+			}
+		})
+	}
+	t.Run("Compatible", func(t *testing.T) {
+		changes := typx.Diff(
+			typx.NewRType(reflect.TypeFor[struct {
+				A int `json:"a"`
+			}]()),
+			typx.NewRType(reflect.TypeFor[struct {
+				A int `json:"a,omitempty"`
+				B int
+			}]()),
+		)
+		Expect(t, changes.Breaking(), BeFalse())
+		Expect(t, changes.String(), Equal(
+			"compatible: Field(A).Tag(json): tag \"a\" => \"a,omitempty\"\n"+
+				"compatible: Field(B): added int",
+		))
+	})
+	t.Run("Nested", func(t *testing.T) {
+		load := func(path, src string) typx.Type {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "api.go", src, 0)
+			Expect(t, err, BeNil[error]())
+			pkg, err := (&types.Config{}).Check(path, fset, []*ast.File{f}, nil)
+			Expect(t, err, BeNil[error]())
+			return typx.NewTType(pkg.Scope().Lookup("Root").Type())
+		}
+		changes := typx.Diff(
+			load("example.com/api/v1", `package api
+type Base struct { ID int }
+type Inner struct { A int }
+type Root struct {
+	Base
+	Inner Inner
+	Ptr   *Inner
+}`),
+			load("example.com/api/v2", `package api
+type Base struct { ID string }
+type Inner struct { A int; B int }
+type Root struct {
+	Base
+	Inner Inner
+	Ptr   *Inner
+}`),
+		)
+		Expect(t, lines(changes), Equal([]string{
+			"breaking: Field(Base).Field(ID): retyped int => string",
+			"compatible: Field(Inner).Field(B): added int",
+		}))
+	})
+}
@@ -0,0 +1,80 @@
+package typx_test
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestDocs(t *testing.T) {
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+
+	t.Run("Type", func(t *testing.T) {
+		x, _ := p.Type("Tagged")
+		Expect(t, strings.HasSuffix(x.Position().Filename, "structure.go"), BeTrue())
+		Expect(t, x.Position().Line > 0, BeTrue())
+		Expect(t, x.Doc(), Equal("Tagged has tagged fields"))
+		Expect(t, x.Comment(), Equal(""))
+
+		x, _ = p.Type("IntAlias")
+		Expect(t, strings.HasSuffix(x.Position().Filename, "basic.go"), BeTrue())
+
+		x = typx.NewTType(typi.NewTTByRT(reflect.TypeFor[fmt.Stringer]()))
+		Expect(t, strings.HasPrefix(x.Doc(), "Stringer is implemented by"), BeTrue())
+
+		x = x.Method(0).Type()
+		Expect(t, x.Position(), Equal(token.Position{}))
+		Expect(t, x.Doc(), Equal(""))
+		Expect(t, x.Comment(), Equal(""))
+
+		x = typx.NewRType(reflect.TypeFor[testdata.Tagged]())
+		Expect(t, x.Position(), Equal(token.Position{}))
+		Expect(t, x.Doc(), Equal(""))
+		Expect(t, x.Comment(), Equal(""))
+	})
+
+	t.Run("StructField", func(t *testing.T) {
+		x := typx.NewTType(typi.NewTTByRT(reflect.TypeFor[testdata.Tagged]()))
+
+		f, _ := x.FieldByName("A")
+		Expect(t, f.Position().Line > 0, BeTrue())
+		Expect(t, f.Position().Line, Equal(x.Position().Line+2))
+		Expect(t, f.Doc(), Equal("A is a tagged string field"))
+		Expect(t, f.Comment(), Equal(""))
+
+		f, _ = x.FieldByName("EmptyInterface")
+		Expect(t, f.Doc(), Equal(""))
+		Expect(t, f.Comment(), Equal("anonymous"))
+
+		f, _ = typx.NewRType(reflect.TypeFor[testdata.Tagged]()).FieldByName("A")
+		Expect(t, f.Position(), Equal(token.Position{}))
+		Expect(t, f.Doc(), Equal(""))
+		Expect(t, f.Comment(), Equal(""))
+	})
+
+	t.Run("Method", func(t *testing.T) {
+		x := typx.NewTType(typi.NewTTByRT(reflect.TypeFor[testdata.Serialized[string]]()))
+
+		m, _ := x.MethodByName("String")
+		Expect(t, strings.HasSuffix(m.Position().Filename, "structure.go"), BeTrue())
+		Expect(t, m.Doc(), Equal("String returns data as string"))
+		Expect(t, m.Comment(), Equal(""))
+
+		m, _ = x.MethodByName("Bytes")
+		Expect(t, m.Doc(), Equal(""))
+
+		m, _ = typx.NewRType(reflect.TypeFor[testdata.Serialized[string]]()).MethodByName("String")
+		Expect(t, m.Position(), Equal(token.Position{}))
+		Expect(t, m.Doc(), Equal(""))
+		Expect(t, m.Comment(), Equal(""))
+	})
+}
@@ -0,0 +1,137 @@
+package typx
+
+import (
+	"cmp"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/xoctopus/typx/internal/typx"
+)
+
+// Enums collects constants of named basic type t declared in package p. the
+// values are ordered by declaration, which is the iota order in a const block.
+func Enums(p *Package, t Type) (*Enum, error) {
+	if t.PkgPath() == "" || t.Name() == "" || !isBasic(t) {
+		return nil, fmt.Errorf("%s is not a named basic type", t)
+	}
+
+	consts := p.Consts(Filter{Unexported: true, Type: t})
+	values := make([]*EnumValue, len(consts))
+	for i, c := range consts {
+		iota, _ := typx.Iota(c.c)
+		values[i] = &EnumValue{Const: c, iota: iota}
+	}
+	slices.SortStableFunc(values, func(a, b *EnumValue) int {
+		pa, pb := a.Position(), b.Position()
+		if c := strings.Compare(pa.Filename, pb.Filename); c != 0 {
+			return c
+		}
+		return cmp.Compare(pa.Offset, pb.Offset)
+	})
+
+	return &Enum{t: t, values: values}, nil
+}
+
+// Enum describes constants of a named basic type
+type Enum struct {
+	t      Type
+	values []*EnumValue
+}
+
+func (e *Enum) Type() Type {
+	return e.t
+}
+
+func (e *Enum) Values() []*EnumValue {
+	return e.values
+}
+
+// Value returns enum value by constant name
+func (e *Enum) Value(name string) (*EnumValue, bool) {
+	for _, v := range e.values {
+		if v.Name() == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Missing returns enum values not covered by values. constants sharing a value
+// are covered together.
+func (e *Enum) Missing(values ...constant.Value) []*EnumValue {
+	missing := make([]*EnumValue, 0)
+	for _, v := range e.values {
+		if !slices.ContainsFunc(values, func(x constant.Value) bool {
+			return compare(v.Value(), x)
+		}) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// Exhaustive checks if values cover all enum values exactly. it reports the
+// missing enum constants and the values are not declared by enum.
+func (e *Enum) Exhaustive(values ...constant.Value) error {
+	var errs []string
+	if missing := e.Missing(values...); len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, v := range missing {
+			names[i] = v.Name()
+		}
+		errs = append(errs, "missing "+strings.Join(names, ", "))
+	}
+	for _, x := range values {
+		if !slices.ContainsFunc(e.values, func(v *EnumValue) bool {
+			return compare(v.Value(), x)
+		}) {
+			errs = append(errs, fmt.Sprintf("unknown value %s", x))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s is not exhaustive: %s", e.t, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// EnumValue describes an enum constant
+type EnumValue struct {
+	*Const
+	iota int
+}
+
+// Iota returns the iota value of constant in its const block
+func (v *EnumValue) Iota() int {
+	return v.iota
+}
+
+// compare reports if constant x equals to y
+func compare(x, y constant.Value) bool {
+	switch {
+	case x.Kind() == constant.Unknown || y.Kind() == constant.Unknown:
+		return false
+	case (x.Kind() == constant.String) != (y.Kind() == constant.String):
+		return false
+	case (x.Kind() == constant.Bool) != (y.Kind() == constant.Bool):
+		return false
+	default:
+		return constant.Compare(x, token.EQL, y)
+	}
+}
+
+func isBasic(t Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.String:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,92 @@
+package typx_test
+
+import (
+	"go/constant"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestEnums(t *testing.T) {
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+
+	status, _ := p.Type("Status")
+	e, err := typx.Enums(p, status)
+	Expect(t, err, BeNil[error]())
+	Expect(t, e.Type().String(), Equal(path+".Status"))
+
+	names := make([]string, 0)
+	for _, v := range e.Values() {
+		names = append(names, v.Name())
+	}
+	Expect(t, names, Equal([]string{
+		"STATUS_UNKNOWN",
+		"STATUS_PENDING",
+		"STATUS_RUNNING",
+		"STATUS_DONE",
+		"status_invalid",
+		"STATUS_DEFAULT",
+	}))
+
+	v, ok := e.Value("STATUS_UNKNOWN")
+	Expect(t, ok, BeTrue())
+	Expect(t, v.Iota(), Equal(0))
+	Expect(t, v.Doc(), Equal("STATUS_UNKNOWN the status is not set"))
+	Expect(t, v.Comment(), Equal(""))
+
+	v, _ = e.Value("STATUS_RUNNING")
+	Expect(t, v.Iota(), Equal(2))
+	Expect(t, v.Doc(), Equal(""))
+	Expect(t, v.Comment(), Equal("running"))
+	Expect(t, v.Value().ExactString(), Equal("2"))
+
+	v, _ = e.Value("STATUS_DEFAULT")
+	Expect(t, v.Iota(), Equal(0))
+	Expect(t, v.Doc(), Equal("STATUS_DEFAULT the default status of a new task"))
+	Expect(t, v.Value().ExactString(), Equal("1"))
+
+	_, ok = e.Value("STATUS_ANY")
+	Expect(t, ok, BeFalse())
+
+	t.Run("Exhaustive", func(t *testing.T) {
+		values := []constant.Value{
+			constant.MakeInt64(0),
+			constant.MakeInt64(1),
+			constant.MakeInt64(2),
+			constant.MakeInt64(3),
+		}
+		missing := e.Missing(values...)
+		Expect(t, len(missing), Equal(1))
+		Expect(t, missing[0].Name(), Equal("status_invalid"))
+		Expect(t, e.Exhaustive(values...), NotBeNil[error]())
+
+		values = append(values, constant.MakeInt64(4))
+		Expect(t, len(e.Missing(values...)), Equal(0))
+		Expect(t, e.Exhaustive(values...), BeNil[error]())
+
+		values = append(values, constant.MakeInt64(5), constant.MakeString("5"))
+		Expect(t, e.Exhaustive(values...), NotBeNil[error]())
+	})
+
+	t.Run("ReflectType", func(t *testing.T) {
+		x, err := typx.Enums(p, typx.NewRType(reflect.TypeFor[testdata.Status]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, len(x.Values()), Equal(len(e.Values())))
+
+		x, err = typx.Enums(p, typx.NewRType(reflect.TypeFor[testdata.Level]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, len(x.Values()), Equal(0))
+	})
+
+	t.Run("NotNamedBasic", func(t *testing.T) {
+		_, err := typx.Enums(p, typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.Enums(p, typx.NewRType(reflect.TypeFor[testdata.Tagged]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+}
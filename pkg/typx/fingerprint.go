@@ -0,0 +1,112 @@
+package typx
+
+import (
+	"crypto/sha256"
+	"go/types"
+	"hash"
+	"reflect"
+	"strconv"
+)
+
+// Fingerprint returns a content hash of t. it is computed over a canonical walk
+// of t, including the structure of named types, struct fields and tags, method
+// signatures of both T and *T, and all transitive element types, so it changes
+// whenever the structure of t changes. rtype and ttype of the same type share a fingerprint.
+func Fingerprint(t Type) [32]byte {
+	f := &fingerprint{h: sha256.New(), visited: make(map[string]int)}
+	f.walk(t)
+
+	sum := [32]byte{}
+	copy(sum[:], f.h.Sum(nil))
+	return sum
+}
+
+type fingerprint struct {
+	h hash.Hash
+	// visited named types with its visiting order
+	visited map[string]int
+}
+
+func (f *fingerprint) write(tokens ...string) {
+	for _, token := range tokens {
+		_, _ = f.h.Write([]byte(strconv.Quote(token)))
+	}
+}
+
+func (f *fingerprint) walk(t Type) {
+	if t == nil {
+		f.write("nil")
+		return
+	}
+
+	if t.Name() != "" {
+		id := t.String()
+		if n, ok := f.visited[id]; ok {
+			// back reference of recursive type
+			f.write("ref", strconv.Itoa(n))
+			return
+		}
+		f.visited[id] = len(f.visited)
+		f.write("named", id)
+	}
+
+	k := t.Kind()
+	f.write(k.String())
+
+	switch k {
+	case reflect.Array:
+		f.write(strconv.Itoa(t.Len()))
+		f.walk(t.Elem())
+	case reflect.Chan:
+		f.write(t.ChanDir().String())
+		f.walk(t.Elem())
+	case reflect.Map:
+		f.walk(t.Key())
+		f.walk(t.Elem())
+	case reflect.Pointer, reflect.Slice:
+		f.walk(t.Elem())
+	case reflect.Func:
+		f.write(strconv.FormatBool(t.IsVariadic()), strconv.Itoa(t.NumIn()), strconv.Itoa(t.NumOut()))
+		for i := range t.NumIn() {
+			f.walk(t.In(i))
+		}
+		for i := range t.NumOut() {
+			f.walk(t.Out(i))
+		}
+	case reflect.Struct:
+		f.write(strconv.Itoa(t.NumField()))
+		for i := range t.NumField() {
+			x := t.Field(i)
+			f.write(x.Name(), x.PkgPath(), strconv.FormatBool(x.Anonymous()), string(x.Tag()))
+			f.walk(x.Type())
+		}
+	}
+
+	// method set of named types or interface
+	if t.Name() != "" || k == reflect.Interface {
+		f.methods(t)
+	}
+	// method set of *T includes the methods with pointer receiver
+	if t.Name() != "" && k != reflect.Interface && k != reflect.Pointer {
+		f.methods(pointerTo(t))
+	}
+}
+
+func (f *fingerprint) methods(t Type) {
+	f.write("methods", strconv.Itoa(t.NumMethod()))
+	for i := range t.NumMethod() {
+		m := t.Method(i)
+		f.write(m.Name(), m.PkgPath())
+		f.walk(m.Type())
+	}
+}
+
+// pointerTo returns pointer type of t in the same backend
+func pointerTo(t Type) Type {
+	switch x := t.(type) {
+	case *ttype:
+		return NewTTypeContext(x.ctx, types.NewPointer(x.t))
+	default:
+		return NewRType(reflect.PointerTo(t.Unwrap().(reflect.Type)))
+	}
+}
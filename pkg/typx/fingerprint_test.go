@@ -0,0 +1,101 @@
+package typx_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestFingerprint(t *testing.T) {
+	fingerprints := func(r reflect.Type) [][32]byte {
+		return [][32]byte{
+			typx.Fingerprint(typx.NewRType(r)),
+			typx.Fingerprint(typx.NewTType(typi.NewTTByRT(r))),
+		}
+	}
+
+	t.Run("Recursive", func(t *testing.T) {
+		x := fingerprints(reflect.TypeFor[testdata.BTreeNode[int]]())
+		Expect(t, x[0], Equal(x[1]))
+		Expect(t, x[0], Equal(typx.Fingerprint(typx.NewRType(reflect.TypeFor[testdata.BTreeNode[int]]()))))
+		Expect(t, x[0], NotEqual(fingerprints(reflect.TypeFor[testdata.BTreeNode[string]]())[0]))
+	})
+
+	t.Run("PointerMethods", func(t *testing.T) {
+		x := fingerprints(reflect.TypeFor[testdata.StringerL1]())
+		Expect(t, x[0], Equal(x[1]))
+		x = fingerprints(reflect.TypeFor[*testdata.StringerL1]())
+		Expect(t, x[0], Equal(x[1]))
+	})
+
+	for _, c := range []struct {
+		name string
+		x, y reflect.Type
+	}{
+		{
+			"Tag",
+			reflect.TypeFor[struct {
+				A int `json:"a"`
+			}](),
+			reflect.TypeFor[struct {
+				A int `json:"b"`
+			}](),
+		},
+		{
+			"FieldType",
+			reflect.TypeFor[struct{ A int }](),
+			reflect.TypeFor[struct{ A int64 }](),
+		},
+		{
+			"Transitive",
+			reflect.TypeFor[map[string][]*struct{ A int }](),
+			reflect.TypeFor[map[string][]*struct{ A uint }](),
+		},
+		{
+			"MethodSignature",
+			reflect.TypeFor[interface{ M(int) }](),
+			reflect.TypeFor[interface{ M(...int) }](),
+		},
+		{
+			"Named",
+			reflect.TypeFor[testdata.String](),
+			reflect.TypeFor[string](),
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			x, y := fingerprints(c.x), fingerprints(c.y)
+			Expect(t, x[0], Equal(x[1]))
+			Expect(t, y[0], Equal(y[1]))
+			Expect(t, x[0], NotEqual(y[0]))
+		})
+	}
+
+	t.Run("PointerReceiver", func(t *testing.T) {
+		load := func(src string) typx.Type {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", "package p\ntype T struct{ A int }\n"+src, 0)
+			Expect(t, err, BeNil[error]())
+			pkg, err := (&types.Config{}).Check("example.com/p", fset, []*ast.File{f}, nil)
+			Expect(t, err, BeNil[error]())
+			return typx.NewTType(pkg.Scope().Lookup("T").Type())
+		}
+		ptr := func(t typx.Type) typx.Type {
+			return typx.NewTType(types.NewPointer(t.Unwrap().(types.Type)))
+		}
+		x := load("func (*T) M(int) {}")
+		for _, y := range []typx.Type{load("func (*T) M(string) {}"), load("")} {
+			Expect(t, typx.Fingerprint(x), NotEqual(typx.Fingerprint(y)))
+			Expect(t, typx.Fingerprint(ptr(x)), NotEqual(typx.Fingerprint(ptr(y))))
+		}
+		Expect(t, typx.Fingerprint(x), Equal(typx.Fingerprint(load("func (*T) M(int) {}"))))
+	})
+}
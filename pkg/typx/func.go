@@ -0,0 +1,151 @@
+package typx
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/xoctopus/x/misc/must"
+
+	"github.com/xoctopus/typx/internal/typx"
+)
+
+// NewFunc wraps a function or method declaration
+func NewFunc(f *types.Func) *Func {
+	must.NotNilF(f, "invalid types.Func")
+	return &Func{f: f}
+}
+
+// LookupFunc lookups function declared in package `path` by name. method should
+// be named as `Recv.Method`, eg: `Max.Compute`
+func LookupFunc(path string, name string) (*Func, error) {
+	p, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := p.Func(name); ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("function %s.%s not found", path, name)
+}
+
+// Func describes a function or method declaration
+type Func struct {
+	f *types.Func
+}
+
+func (f *Func) Unwrap() *types.Func {
+	return f.f
+}
+
+func (f *Func) PkgPath() string {
+	if pkg := f.f.Pkg(); pkg != nil {
+		return pkg.Path()
+	}
+	return ""
+}
+
+func (f *Func) Name() string {
+	return f.f.Name()
+}
+
+// String returns full qualified name of function, eg:
+// `path/to/pkg.Func` or `(path/to/pkg.Recv[T]).Method`
+func (f *Func) String() string {
+	return f.f.FullName()
+}
+
+func (f *Func) Exported() bool {
+	return f.f.Exported()
+}
+
+// Recv returns receiver type of method, returns nil if f is not a method
+func (f *Func) Recv() Type {
+	if recv := f.f.Signature().Recv(); recv != nil {
+		return NewTType(recv.Type())
+	}
+	return nil
+}
+
+// TypeParams returns type parameters of generic function, or the receiver's
+// type parameters of method
+func (f *Func) TypeParams() []*TypeParam {
+	s := f.f.Signature()
+	tparams := s.TypeParams()
+	if s.Recv() != nil {
+		tparams = s.RecvTypeParams()
+	}
+	params := make([]*TypeParam, tparams.Len())
+	for i := range tparams.Len() {
+		params[i] = &TypeParam{p: tparams.At(i)}
+	}
+	return params
+}
+
+// Signature returns function signature without receiver
+func (f *Func) Signature() Type {
+	return NewTType(f.f.Signature())
+}
+
+// Doc returns doc comment of function declaration
+func (f *Func) Doc() string {
+	doc, _ := typx.Doc(f.f)
+	return doc
+}
+
+// Position returns source position of function declaration
+func (f *Func) Position() token.Position {
+	return typx.Position(f.f)
+}
+
+// InstantiateFunc instantiates generic function or method of generic type with
+// type arguments, returns the concrete signature without receiver. args follow
+// the same rules as Instantiate.
+func InstantiateFunc(f *Func, args ...Type) (Type, error) {
+	s := f.f.Signature()
+
+	if s.Recv() != nil && s.RecvTypeParams().Len() > 0 {
+		recv := s.Recv().Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		generic := NewTType(recv.(*types.Named).Origin())
+		inst, err := Instantiate(generic, args...)
+		if err != nil {
+			return nil, err
+		}
+		n := inst.Unwrap().(*types.Named)
+		for i := range n.NumMethods() {
+			if m := n.Method(i); m.Name() == f.Name() {
+				return NewTType(m.Signature()), nil
+			}
+		}
+		return nil, fmt.Errorf("method %s not found in %s", f.Name(), inst)
+	}
+
+	if s.TypeParams().Len() == 0 {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%s is not a generic function", f)
+		}
+		return f.Signature(), nil
+	}
+	return Instantiate(f.Signature(), args...)
+}
+
+// TypeParam describes a type parameter of generic type or function
+type TypeParam struct {
+	p *types.TypeParam
+}
+
+func (p *TypeParam) Index() int {
+	return p.p.Index()
+}
+
+func (p *TypeParam) Name() string {
+	return p.p.Obj().Name()
+}
+
+// Constraint returns the constraint literal of type parameter, eg: `~[]E`
+func (p *TypeParam) Constraint() string {
+	return p.p.Constraint().String()
+}
@@ -0,0 +1,99 @@
+package typx_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/typx"
+)
+
+func TestFunc(t *testing.T) {
+	t.Run("Func", func(t *testing.T) {
+		f, err := typx.LookupFunc(path, "Sum")
+		Expect(t, err, BeNil[error]())
+		Expect(t, f.Name(), Equal("Sum"))
+		Expect(t, f.PkgPath(), Equal(path))
+		Expect(t, f.String(), Equal(path+".Sum"))
+		Expect(t, f.Exported(), BeTrue())
+		Expect(t, f.Recv(), BeNil[typx.Type]())
+		Expect(t, len(f.TypeParams()), Equal(0))
+		Expect(t, f.Signature().String(), Equal("func(...int) int"))
+		Expect(t, f.Signature().IsVariadic(), BeTrue())
+		Expect(t, f.Doc(), Equal("Sum returns the sum of v"))
+		Expect(t, f.Position().Filename != "", BeTrue())
+		Expect(t, f.Position().Line > 0, BeTrue())
+
+		s, err := typx.InstantiateFunc(f)
+		Expect(t, err, BeNil[error]())
+		Expect(t, s.String(), Equal("func(...int) int"))
+
+		_, err = typx.InstantiateFunc(f, typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("GenericFunc", func(t *testing.T) {
+		f, err := typx.LookupFunc(path, "Apply")
+		Expect(t, err, BeNil[error]())
+		Expect(t, f.Doc(), Equal("Apply calls f with each element of s and collects the results"))
+
+		tparams := f.TypeParams()
+		Expect(t, len(tparams), Equal(3))
+		Expect(t, tparams[0].Index(), Equal(0))
+		Expect(t, tparams[0].Name(), Equal("S"))
+		Expect(t, tparams[0].Constraint(), Equal("~[]E"))
+		Expect(t, tparams[2].Name(), Equal("R"))
+		Expect(t, tparams[2].Constraint(), Equal("any"))
+		Expect(t, f.Signature().String(), Equal("func(S, func(E) R) []R"))
+
+		s, err := typx.InstantiateFunc(f,
+			typx.NewRType(reflect.TypeFor[[]int]()), nil,
+			typx.NewRType(reflect.TypeFor[string]()),
+		)
+		Expect(t, err, BeNil[error]())
+		Expect(t, s.String(), Equal(typx.NewRType(reflect.TypeFor[func([]int, func(int) string) []string]()).String()))
+
+		_, err = typx.InstantiateFunc(f, typx.NewRType(reflect.TypeFor[[]int]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("MethodOfGeneric", func(t *testing.T) {
+		f, err := typx.LookupFunc(path, "Max.Compute")
+		Expect(t, err, BeNil[error]())
+		Expect(t, f.Name(), Equal("Compute"))
+		Expect(t, f.Recv().String(), Equal(path+".Max[T]"))
+		Expect(t, f.Doc(), Equal("Compute returns the max value of e"))
+		Expect(t, len(f.TypeParams()), Equal(1))
+		Expect(t, f.TypeParams()[0].Constraint(), Equal("comparable"))
+
+		s, err := typx.InstantiateFunc(f, typx.NewRType(reflect.TypeFor[string]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, s.String(), Equal("func(...string) string"))
+
+		_, err = typx.InstantiateFunc(f, typx.NewRType(reflect.TypeFor[func()]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("Method", func(t *testing.T) {
+		f, err := typx.LookupFunc(path, "StringerL1.String")
+		Expect(t, err, BeNil[error]())
+		Expect(t, f.Recv().String(), Equal("*"+path+".StringerL1"))
+		Expect(t, f.Doc(), Equal(""))
+
+		s, err := typx.InstantiateFunc(f)
+		Expect(t, err, BeNil[error]())
+		Expect(t, s.String(), Equal("func() string"))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := typx.LookupFunc(path, "NotFound")
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.LookupFunc(path, "Max.NotFound")
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.LookupFunc(path, "Int")
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.LookupFunc(path+"/not/found", "Any")
+		Expect(t, err, NotBeNil[error]())
+	})
+}
@@ -0,0 +1,305 @@
+package typx
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EdgeKind describes how a named type references another
+type EdgeKind string
+
+const (
+	EdgeField   EdgeKind = "field"
+	EdgeEmbed   EdgeKind = "embed"
+	EdgeElem    EdgeKind = "elem"
+	EdgeKey     EdgeKind = "key"
+	EdgeTypeArg EdgeKind = "typearg"
+	EdgeParam   EdgeKind = "param"
+	EdgeResult  EdgeKind = "result"
+)
+
+// NewGraph builds the reference graph of named types reachable from roots. the
+// type argument edges are recorded only for types from go/types, see Walk.
+func NewGraph(roots ...Type) *Graph {
+	return build(roots, func(Type) bool { return true })
+}
+
+// NewPackageGraph builds the reference graph of types declared in pkgs,
+// including unexported types. types declared outside pkgs are included as leaf
+// nodes without outgoing edges. uninstantiated generic types have no outgoing
+// edges, the references of their instantiations are recorded instead.
+func NewPackageGraph(pkgs ...*Package) *Graph {
+	roots := make([]Type, 0)
+	paths := make(map[string]bool)
+	for _, p := range pkgs {
+		paths[p.Path()] = true
+		roots = append(roots, p.Types(Filter{Unexported: true})...)
+	}
+	return build(roots, func(t Type) bool { return paths[t.PkgPath()] })
+}
+
+// Graph is a directed graph of named types, the edges are references from one
+// named type to another through fields, elements, type arguments and method
+// signatures.
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+
+	index map[string]*GraphNode
+}
+
+// GraphNode is a named type in graph
+type GraphNode struct {
+	// ID is the full qualified type name
+	ID      string `json:"id"`
+	PkgPath string `json:"pkgPath"`
+	Name    string `json:"name"`
+	Type    Type   `json:"-"`
+}
+
+// GraphEdge is a reference between named types
+type GraphEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+	// Label is the field or method name of the reference
+	Label string `json:"label,omitempty"`
+}
+
+// Node returns node by id
+func (g *Graph) Node(id string) (*GraphNode, bool) {
+	n, ok := g.index[id]
+	return n, ok
+}
+
+// SCCs returns strongly connected components of graph in reverse topological
+// order, the nodes of each component are sorted by id.
+func (g *Graph) SCCs() [][]*GraphNode {
+	adjacent := make(map[string][]string)
+	for _, e := range g.Edges {
+		adjacent[e.From] = append(adjacent[e.From], e.To)
+	}
+
+	// tarjan's algorithm
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onstack = make(map[string]bool)
+		stack   []string
+		sccs    [][]*GraphNode
+		connect func(v string)
+	)
+	connect = func(v string) {
+		index[v] = len(index)
+		lowlink[v] = index[v]
+		stack = append(stack, v)
+		onstack[v] = true
+
+		for _, w := range adjacent[v] {
+			if _, ok := index[w]; !ok {
+				connect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onstack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+
+		if lowlink[v] == index[v] {
+			scc := make([]*GraphNode, 0)
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onstack[w] = false
+				scc = append(scc, g.index[w])
+				if w == v {
+					break
+				}
+			}
+			slices.SortFunc(scc, func(a, b *GraphNode) int { return strings.Compare(a.ID, b.ID) })
+			sccs = append(sccs, scc)
+		}
+	}
+
+	for _, n := range g.Nodes {
+		if _, ok := index[n.ID]; !ok {
+			connect(n.ID)
+		}
+	}
+	return sccs
+}
+
+// Cycles returns strongly connected components which contain reference cycles
+func (g *Graph) Cycles() [][]*GraphNode {
+	cycles := make([][]*GraphNode, 0)
+	for _, scc := range g.SCCs() {
+		if len(scc) > 1 || slices.ContainsFunc(g.Edges, func(e *GraphEdge) bool {
+			return e.From == scc[0].ID && e.To == scc[0].ID
+		}) {
+			cycles = append(cycles, scc)
+		}
+	}
+	return cycles
+}
+
+// DOT renders graph in Graphviz DOT language. nodes are clustered by package and
+// nodes in cycles are highlighted.
+func (g *Graph) DOT() string {
+	cyclic := make(map[string]bool)
+	for _, scc := range g.Cycles() {
+		for _, n := range scc {
+			cyclic[n.ID] = true
+		}
+	}
+
+	packages := make([]string, 0)
+	clusters := make(map[string][]*GraphNode)
+	for _, n := range g.Nodes {
+		if _, ok := clusters[n.PkgPath]; !ok {
+			packages = append(packages, n.PkgPath)
+		}
+		clusters[n.PkgPath] = append(clusters[n.PkgPath], n)
+	}
+
+	b := strings.Builder{}
+	b.WriteString("digraph types {\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for i, pkg := range packages {
+		_, _ = fmt.Fprintf(&b, "\tsubgraph cluster_%d {\n", i)
+		_, _ = fmt.Fprintf(&b, "\t\tlabel=%s;\n", strconv.Quote(pkg))
+		for _, n := range clusters[pkg] {
+			attrs := "label=" + strconv.Quote(path.Base(n.PkgPath)+"."+n.Name)
+			if cyclic[n.ID] {
+				attrs += ", color=red"
+			}
+			_, _ = fmt.Fprintf(&b, "\t\t%s [%s];\n", strconv.Quote(n.ID), attrs)
+		}
+		b.WriteString("\t}\n")
+	}
+	for _, e := range g.Edges {
+		label := string(e.Kind)
+		if e.Label != "" {
+			label += ":" + e.Label
+		}
+		_, _ = fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(label))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// JSON renders graph as JSON object with nodes, edges and cycles
+func (g *Graph) JSON() ([]byte, error) {
+	cycles := make([][]string, 0)
+	for _, scc := range g.Cycles() {
+		ids := make([]string, len(scc))
+		for i, n := range scc {
+			ids[i] = n.ID
+		}
+		cycles = append(cycles, ids)
+	}
+	return json.MarshalIndent(struct {
+		*Graph
+		Cycles [][]string `json:"cycles"`
+	}{g, cycles}, "", "  ")
+}
+
+func build(roots []Type, scope func(Type) bool) *Graph {
+	g := &Graph{
+		Nodes: make([]*GraphNode, 0),
+		Edges: make([]*GraphEdge, 0),
+		index: make(map[string]*GraphNode),
+	}
+	edges := make(map[GraphEdge]bool)
+
+	var pending []*GraphNode
+	node := func(t Type) *GraphNode {
+		id := t.String()
+		if n, ok := g.index[id]; ok {
+			return n
+		}
+		n := &GraphNode{ID: id, PkgPath: t.PkgPath(), Name: t.Name(), Type: t}
+		g.index[id] = n
+		g.Nodes = append(g.Nodes, n)
+		pending = append(pending, n)
+		return n
+	}
+
+	for _, t := range roots {
+		if t.PkgPath() != "" && t.Name() != "" {
+			node(t)
+		}
+	}
+	expanded := make(map[string]bool)
+	for len(pending) > 0 {
+		n := pending[0]
+		pending = pending[1:]
+		if !expanded[n.ID] && scope(n.Type) {
+			expanded[n.ID] = true
+			Inspect(n.Type, func(c *Cursor) bool {
+				if c.Depth() == 0 || c.Type.PkgPath() == "" || c.Type.Name() == "" {
+					return true
+				}
+				if kind, label := reference(n.Type, c.Path()); kind != "" {
+					e := GraphEdge{From: n.ID, To: node(c.Type).ID, Kind: kind, Label: label}
+					if !edges[e] {
+						edges[e] = true
+						g.Edges = append(g.Edges, &e)
+					}
+				}
+				return false
+			})
+		}
+	}
+
+	slices.SortFunc(g.Nodes, func(a, b *GraphNode) int { return strings.Compare(a.ID, b.ID) })
+	slices.SortFunc(g.Edges, func(a, b *GraphEdge) int {
+		return cmp.Or(
+			strings.Compare(a.From, b.From),
+			strings.Compare(a.To, b.To),
+			strings.Compare(string(a.Kind), string(b.Kind)),
+			strings.Compare(a.Label, b.Label),
+		)
+	})
+	return g
+}
+
+// reference returns the edge kind and label of the path from named type t. the
+// receiver of methods is not a reference.
+func reference(t Type, steps []Step) (EdgeKind, string) {
+	first := steps[0]
+	switch first.Kind {
+	case StepField:
+		if t.Field(first.Index).Anonymous() {
+			return EdgeEmbed, first.Name
+		}
+		return EdgeField, first.Name
+	case StepElem:
+		return EdgeElem, ""
+	case StepKey:
+		return EdgeKey, ""
+	case StepTypeArg:
+		return EdgeTypeArg, ""
+	case StepIn:
+		return EdgeParam, ""
+	case StepOut:
+		return EdgeResult, ""
+	default: // StepMethod
+		if len(steps) < 2 {
+			return "", ""
+		}
+		switch second := steps[1]; second.Kind {
+		case StepIn:
+			if second.Index == 0 && t.Kind() != reflect.Interface {
+				return "", ""
+			}
+			return EdgeParam, first.Name
+		default:
+			return EdgeResult, first.Name
+		}
+	}
+}
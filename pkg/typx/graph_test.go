@@ -0,0 +1,132 @@
+package typx_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestGraph(t *testing.T) {
+	ids := func(nodes []*typx.GraphNode) []string {
+		s := make([]string, len(nodes))
+		for i, n := range nodes {
+			s[i] = strings.TrimPrefix(n.ID, path+".")
+		}
+		return s
+	}
+	edges := func(g *typx.Graph) []string {
+		s := make([]string, len(g.Edges))
+		for i, e := range g.Edges {
+			s[i] = strings.TrimPrefix(e.From, path+".") + " " + string(e.Kind) + ":" + e.Label + " " + strings.TrimPrefix(e.To, path+".")
+		}
+		return s
+	}
+
+	t.Run("Roots", func(t *testing.T) {
+		for _, root := range []typx.Type{
+			typx.NewRType(reflect.TypeFor[testdata.Department]()),
+			typx.NewTType(typi.NewTTByRT(reflect.TypeFor[testdata.Department]())),
+		} {
+			g := typx.NewGraph(root)
+			Expect(t, ids(g.Nodes), Equal([]string{
+				"Department", "Employee", "Profile", "Serialized[string]", "String",
+			}))
+			Expect(t, edges(g), Equal([]string{
+				"Department field:Manager Employee",
+				"Department field:Members Employee",
+				"Employee field:Department Department",
+				"Employee embed:Profile Profile",
+				"Employee result:Lookup Serialized[string]",
+				"Employee field:Name String",
+				"Employee param:Lookup String",
+				"Profile field:Tags Serialized[string]",
+				"Profile result:Lookup Serialized[string]",
+				"Profile field:Tags String",
+				"Profile param:Lookup String",
+			}))
+
+			cycles := g.Cycles()
+			Expect(t, len(cycles), Equal(1))
+			Expect(t, ids(cycles[0]), Equal([]string{"Department", "Employee"}))
+
+			sccs := g.SCCs()
+			Expect(t, len(sccs), Equal(4))
+			// reverse topological order: the referenced come first
+			Expect(t, ids(sccs[len(sccs)-1]), Equal([]string{"Department", "Employee"}))
+
+			n, ok := g.Node(path + ".Employee")
+			Expect(t, ok, BeTrue())
+			Expect(t, n.Name, Equal("Employee"))
+			Expect(t, n.Type.String(), Equal(path+".Employee"))
+		}
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		g := typx.NewGraph(typx.NewRType(reflect.TypeFor[testdata.BTreeNode[int]]()))
+		Expect(t, ids(g.Nodes), Equal([]string{"BTreeNode[int]"}))
+		Expect(t, edges(g), Equal([]string{
+			"BTreeNode[int] field:l BTreeNode[int]",
+			"BTreeNode[int] field:p BTreeNode[int]",
+			"BTreeNode[int] field:r BTreeNode[int]",
+		}))
+		Expect(t, len(g.Cycles()), Equal(1))
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		p, err := typx.Load(path)
+		Expect(t, err, BeNil[error]())
+
+		g := typx.NewPackageGraph(p)
+		for _, e := range g.Edges {
+			from, _ := g.Node(e.From)
+			Expect(t, from.PkgPath, Equal(path))
+		}
+		_, ok := g.Node("net.Addr")
+		Expect(t, ok, BeTrue())
+		cycles := make([][]string, 0)
+		for _, c := range g.Cycles() {
+			cycles = append(cycles, ids(c))
+		}
+		Expect(t, slices.ContainsFunc(cycles, func(c []string) bool {
+			return slices.Equal(c, []string{"Department", "Employee"})
+		}), BeTrue())
+	})
+
+	t.Run("Render", func(t *testing.T) {
+		g := typx.NewGraph(typx.NewRType(reflect.TypeFor[testdata.Department]()))
+
+		dot := g.DOT()
+		Expect(t, strings.HasPrefix(dot, "digraph types {\n"), BeTrue())
+		Expect(t, strings.Contains(dot, `label="github.com/xoctopus/typx/testdata";`), BeTrue())
+		Expect(t, strings.Contains(dot, `"`+path+`.Department" [label="testdata.Department", color=red];`), BeTrue())
+		Expect(t, strings.Contains(dot, `"`+path+`.Profile" [label="testdata.Profile"];`), BeTrue())
+		Expect(t, strings.Contains(dot, `"`+path+`.Employee" -> "`+path+`.Profile" [label="embed:Profile"];`), BeTrue())
+
+		data, err := g.JSON()
+		Expect(t, err, BeNil[error]())
+		v := struct {
+			Nodes  []map[string]string `json:"nodes"`
+			Edges  []map[string]string `json:"edges"`
+			Cycles [][]string          `json:"cycles"`
+		}{}
+		Expect(t, json.Unmarshal(data, &v), BeNil[error]())
+		Expect(t, len(v.Nodes), Equal(len(g.Nodes)))
+		Expect(t, len(v.Edges), Equal(len(g.Edges)))
+		Expect(t, v.Nodes[0]["id"], Equal(path+".Department"))
+		Expect(t, v.Edges[0], Equal(map[string]string{
+			"from":  path + ".Department",
+			"to":    path + ".Employee",
+			"kind":  "field",
+			"label": "Manager",
+		}))
+		Expect(t, v.Cycles, Equal([][]string{{path + ".Department", path + ".Employee"}}))
+	})
+}
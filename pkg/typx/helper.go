@@ -10,6 +10,10 @@ import (
 
 var CtxPkgNamer = dumper.CtxPkgNamer
 
+// CtxWrapID wraps import paths of type literals as identifiers in TypeLit, eg:
+// `github_com_xoctopus_typx_testdata.Page`
+var CtxWrapID = dumper.CtxWrapID
+
 func Deref(t Type) Type {
 	for t.Kind() == reflect.Pointer && t.Name() == "" {
 		t = t.Elem()
@@ -0,0 +1,115 @@
+package typx
+
+import (
+	"go/types"
+	"reflect"
+)
+
+// Identical reports whether x and y are identical types. it follows the type
+// identity rules of Go spec and works across reflect and go/types backends:
+// named types are identical if they are from the same declaration with the
+// identical type arguments; struct types compare field names, tags, embedding
+// and package paths of unexported fields; function types compare variadicity.
+func Identical(x, y Type) bool {
+	return identical(x, y, true)
+}
+
+// IdenticalIgnoreTags is like Identical but ignores struct tags
+func IdenticalIgnoreTags(x, y Type) bool {
+	return identical(x, y, false)
+}
+
+func identical(x, y Type, tags bool) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+
+	switch ux := x.Unwrap().(type) {
+	case reflect.Type:
+		if uy, ok := y.Unwrap().(reflect.Type); ok && tags {
+			return ux == uy
+		}
+	case types.Type:
+		if uy, ok := y.Unwrap().(types.Type); ok {
+			if tags {
+				return types.Identical(ux, uy)
+			}
+			return types.IdenticalIgnoreTags(ux, uy)
+		}
+	}
+
+	// wrapped id contains everything except package paths of unexported fields
+	if tags && x.String() != y.String() {
+		return false
+	}
+	return structural(x, y, tags)
+}
+
+func structural(x, y Type, tags bool) bool {
+	if x.Kind() != y.Kind() {
+		return false
+	}
+
+	if x.Name() != "" || y.Name() != "" {
+		return x.PkgPath() == y.PkgPath() && x.Name() == y.Name()
+	}
+
+	switch x.Kind() {
+	case reflect.Array:
+		return x.Len() == y.Len() && structural(x.Elem(), y.Elem(), tags)
+	case reflect.Chan:
+		return x.ChanDir() == y.ChanDir() && structural(x.Elem(), y.Elem(), tags)
+	case reflect.Map:
+		return structural(x.Key(), y.Key(), tags) && structural(x.Elem(), y.Elem(), tags)
+	case reflect.Pointer, reflect.Slice:
+		return structural(x.Elem(), y.Elem(), tags)
+	case reflect.Func:
+		if x.IsVariadic() != y.IsVariadic() || x.NumIn() != y.NumIn() || x.NumOut() != y.NumOut() {
+			return false
+		}
+		for i := range x.NumIn() {
+			if !structural(x.In(i), y.In(i), tags) {
+				return false
+			}
+		}
+		for i := range x.NumOut() {
+			if !structural(x.Out(i), y.Out(i), tags) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		if x.NumMethod() != y.NumMethod() {
+			return false
+		}
+		for i := range x.NumMethod() {
+			mx, my := x.Method(i), y.Method(i)
+			if mx.Name() != my.Name() || mx.PkgPath() != my.PkgPath() {
+				return false
+			}
+			if !structural(mx.Type(), my.Type(), tags) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if x.NumField() != y.NumField() {
+			return false
+		}
+		for i := range x.NumField() {
+			fx, fy := x.Field(i), y.Field(i)
+			if fx.Name() != fy.Name() || fx.Anonymous() != fy.Anonymous() || fx.PkgPath() != fy.PkgPath() {
+				return false
+			}
+			if tags && fx.Tag() != fy.Tag() {
+				return false
+			}
+			if !structural(fx.Type(), fy.Type(), tags) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
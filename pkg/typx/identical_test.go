@@ -0,0 +1,129 @@
+package typx_test
+
+import (
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestIdentical(t *testing.T) {
+	both := func(r reflect.Type) []typx.Type {
+		return []typx.Type{typx.NewRType(r), typx.NewTType(typi.NewTTByRT(r))}
+	}
+
+	for _, c := range []struct {
+		name   string
+		x, y   reflect.Type
+		expect bool
+		ignore bool
+	}{
+		{
+			"Same",
+			reflect.TypeFor[testdata.BTreeNode[int]](),
+			reflect.TypeFor[testdata.BTreeNode[int]](),
+			true, true,
+		},
+		{
+			"DifferentTypeArgs",
+			reflect.TypeFor[testdata.BTreeNode[int]](),
+			reflect.TypeFor[testdata.BTreeNode[string]](),
+			false, false,
+		},
+		{
+			"NamedAndUnderlying",
+			reflect.TypeFor[testdata.String](),
+			reflect.TypeFor[string](),
+			false, false,
+		},
+		{
+			"Tags",
+			reflect.TypeFor[struct {
+				A int `json:"a"`
+			}](),
+			reflect.TypeFor[struct {
+				A int `json:"b"`
+			}](),
+			false, true,
+		},
+		{
+			"Variadic",
+			reflect.TypeFor[func(...int)](),
+			reflect.TypeFor[func([]int)](),
+			false, false,
+		},
+		{
+			"ChanDir",
+			reflect.TypeFor[chan<- int](),
+			reflect.TypeFor[<-chan int](),
+			false, false,
+		},
+		{
+			"Interface",
+			reflect.TypeFor[interface{ String() string }](),
+			reflect.TypeFor[interface{ String() string }](),
+			true, true,
+		},
+		{
+			"InterfaceMethods",
+			reflect.TypeFor[interface{ String() string }](),
+			reflect.TypeFor[interface{ Bytes() []byte }](),
+			false, false,
+		},
+		{
+			"Embedded",
+			reflect.TypeFor[struct{ testdata.String }](),
+			reflect.TypeFor[struct{ String testdata.String }](),
+			false, false,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			for _, x := range both(c.x) {
+				for _, y := range both(c.y) {
+					Expect(t, typx.Identical(x, y), Equal(c.expect))
+					Expect(t, typx.IdenticalIgnoreTags(x, y), Equal(c.ignore))
+				}
+			}
+		})
+	}
+
+	t.Run("UnexportedFieldPkgPath", func(t *testing.T) {
+		x := typx.NewRType(reflect.TypeFor[struct{ some any }]())
+		field := func(path string) typx.Type {
+			pkg := types.NewPackage(path, filepath.Base(path))
+			return typx.NewTType(types.NewStruct(
+				[]*types.Var{types.NewField(0, pkg, "some", types.NewInterfaceType(nil, nil), false)},
+				nil,
+			))
+		}
+		y := field(x.Field(0).PkgPath())
+		Expect(t, x.String(), Equal(y.String()))
+		Expect(t, typx.Identical(x, y), BeTrue())
+		Expect(t, typx.Identical(y, x), BeTrue())
+
+		y = field(path)
+		Expect(t, x.String(), Equal(y.String()))
+		Expect(t, typx.Identical(x, y), BeFalse())
+		Expect(t, typx.IdenticalIgnoreTags(x, y), BeFalse())
+	})
+
+	t.Run("Instantiated", func(t *testing.T) {
+		p, _ := typx.Load(path)
+		g, _ := p.Type("Serialized")
+		x, err := typx.Instantiate(g, typx.NewRType(reflect.TypeFor[string]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, typx.Identical(x, typx.NewRType(reflect.TypeFor[testdata.Serialized[string]]())), BeTrue())
+		Expect(t, typx.Identical(x, typx.NewRType(reflect.TypeFor[testdata.Serialized[[]byte]]())), BeFalse())
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		Expect(t, typx.Identical(nil, nil), BeTrue())
+		Expect(t, typx.Identical(typx.NewRType(reflect.TypeFor[int]()), nil), BeFalse())
+	})
+}
@@ -0,0 +1,182 @@
+package typx
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Implementers returns the named types declared in pkgs which implement
+// interface iface, T is returned if T implements iface, otherwise *T is
+// returned if *T does. unexported types are included, and interface types are
+// excluded. the method sets are inspected as Type.Method does.
+//
+// generic types are checked by their instantiations with known type arguments,
+// which are referenced by declarations in pkgs, eg: `Serialized[string]` in
+// `func (Profile) Lookup(String) (Serialized[string], error)`, or inferred by
+// the core types of type parameter constraints, eg: `Counter[T ~int]`.
+func Implementers(iface Type, pkgs ...*Package) ([]Type, error) {
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%s is not an interface", typeName(iface))
+	}
+	if g, _ := uninstantiated(iface); g != nil {
+		return nil, fmt.Errorf("%s is uninstantiated", iface)
+	}
+	it, err := bridge(iface)
+	if err != nil {
+		return nil, err
+	}
+	x := it.Underlying().(*types.Interface)
+
+	known := instances(pkgs)
+	implementers := make([]Type, 0)
+	for _, p := range pkgs {
+		for _, t := range p.Types(Filter{Unexported: true}) {
+			candidates := []Type{t}
+			if g, _ := uninstantiated(t); g != nil {
+				candidates = known[g.(*types.Named).Obj()]
+				if inferred, err := Instantiate(t); err == nil && !slices.ContainsFunc(candidates, func(c Type) bool {
+					return Identical(c, inferred)
+				}) {
+					candidates = append(candidates, inferred)
+				}
+			}
+			for _, c := range candidates {
+				if impl := implementer(c, x); impl != nil {
+					implementers = append(implementers, impl)
+				}
+			}
+		}
+	}
+	return implementers, nil
+}
+
+// implementer returns t or *t which implements iface
+func implementer(t Type, iface *types.Interface) Type {
+	if t.Kind() == reflect.Interface {
+		return nil
+	}
+	if implements(t, iface) {
+		return t
+	}
+	if u, err := bridge(t); err == nil && t.Kind() != reflect.Pointer {
+		if p := NewTType(types.NewPointer(u)); implements(p, iface) {
+			return p
+		}
+	}
+	return nil
+}
+
+// implements reports if method set of t has all methods of iface. the method
+// set excludes unexported methods, so iface with unexported methods is checked
+// by go/types, so are the methods of t not from go/types.
+func implements(t Type, iface *types.Interface) bool {
+	for i := range iface.NumMethods() {
+		m := iface.Method(i)
+		x, ok := t.MethodByName(m.Name())
+		tm, typed := x.(*TMethod)
+		if !m.Exported() || ok && !typed {
+			u, err := bridge(t)
+			return err == nil && types.Implements(u, iface)
+		}
+		if !ok || !types.Identical(tm.f.Type(), m.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// instances returns instantiated generic types referenced by declarations in
+// pkgs, keyed by their generic type names
+func instances(pkgs []*Package) map[*types.TypeName][]Type {
+	var (
+		known   = make(map[*types.TypeName][]Type)
+		visited = make(map[string]bool)
+		walk    func(types.Type)
+	)
+	walk = func(t types.Type) {
+		key := types.TypeString(t, nil)
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+
+		switch x := t.(type) {
+		case *types.Alias:
+			walk(x.Rhs())
+		case *types.Named:
+			if x.TypeArgs().Len() == 0 {
+				return
+			}
+			obj := x.Origin().Obj()
+			known[obj] = append(known[obj], NewTType(x))
+			for i := range x.TypeArgs().Len() {
+				walk(x.TypeArgs().At(i))
+			}
+			walk(x.Underlying())
+		case *types.Pointer:
+			walk(x.Elem())
+		case *types.Slice:
+			walk(x.Elem())
+		case *types.Array:
+			walk(x.Elem())
+		case *types.Chan:
+			walk(x.Elem())
+		case *types.Map:
+			walk(x.Key())
+			walk(x.Elem())
+		case *types.Struct:
+			for i := range x.NumFields() {
+				walk(x.Field(i).Type())
+			}
+		case *types.Interface:
+			for i := range x.NumExplicitMethods() {
+				walk(x.ExplicitMethod(i).Type())
+			}
+			for i := range x.NumEmbeddeds() {
+				walk(x.EmbeddedType(i))
+			}
+		case *types.Signature:
+			if x.TypeParams().Len() > 0 || x.RecvTypeParams().Len() > 0 {
+				return
+			}
+			for _, tuple := range []*types.Tuple{x.Params(), x.Results()} {
+				for i := range tuple.Len() {
+					walk(tuple.At(i).Type())
+				}
+			}
+		}
+	}
+
+	for _, p := range pkgs {
+		scope := p.Unwrap().Scope()
+		for _, name := range scope.Names() {
+			switch obj := scope.Lookup(name).(type) {
+			case *types.TypeName:
+				// type parameters only appear in generic declarations
+				switch x := obj.Type().(type) {
+				case *types.Alias:
+					if x.TypeParams().Len() == 0 {
+						walk(x)
+					}
+				case *types.Named:
+					if x.TypeParams().Len() > 0 {
+						continue
+					}
+					walk(x.Underlying())
+					for i := range x.NumMethods() {
+						walk(x.Method(i).Type())
+					}
+				}
+			case *types.Func, *types.Var:
+				walk(obj.Type())
+			}
+		}
+	}
+	for _, ts := range known {
+		slices.SortFunc(ts, func(x, y Type) int { return strings.Compare(x.String(), y.String()) })
+	}
+	return known
+}
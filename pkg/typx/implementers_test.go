@@ -0,0 +1,67 @@
+package typx_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestImplementers(t *testing.T) {
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+
+	names := func(ts []typx.Type) []string {
+		s := make([]string, len(ts))
+		for i, x := range ts {
+			s[i] = x.String()
+		}
+		return s
+	}
+
+	for _, iface := range []typx.Type{
+		typx.NewRType(reflect.TypeFor[fmt.Stringer]()),
+		typx.NewTType(typi.NewTTByRT(reflect.TypeFor[fmt.Stringer]())),
+	} {
+		ts, err := typx.Implementers(iface, p)
+		Expect(t, err, BeNil[error]())
+		Expect(t, names(ts), Equal([]string{
+			// inferred by constraint
+			path + ".Counter[int]",
+			// referenced by declarations
+			path + ".Serialized[[]uint8]",
+			path + ".Serialized[string]",
+			"*" + path + ".StringerL1",
+			path + ".StringerL2",
+			path + ".StringerL2WrapL1",
+			path + ".StringerL3",
+			path + ".StringerL3WrapL2",
+			path + ".UnambiguousL1AndL2x2",
+			path + ".UnambiguousL2AndL3x2",
+		}))
+	}
+
+	ts, err := typx.Implementers(typx.NewRType(reflect.TypeFor[testdata.Shape]()), p)
+	Expect(t, err, BeNil[error]())
+	Expect(t, names(ts), Equal([]string{path + ".Circle", "*" + path + ".Rect"}))
+
+	ts, err = typx.Implementers(typx.NewRType(reflect.TypeFor[any]()))
+	Expect(t, err, BeNil[error]())
+	Expect(t, len(ts), Equal(0))
+
+	// declared in function scope cannot be bridged to go/types
+	type declared interface{ Declared() }
+	for _, x := range []typx.Type{
+		nil,
+		typx.NewRType(reflect.TypeFor[int]()),
+		typx.NewRType(reflect.TypeFor[declared]()),
+	} {
+		_, err = typx.Implementers(x, p)
+		Expect(t, err, NotBeNil[error]())
+	}
+}
@@ -0,0 +1,89 @@
+package typx
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+
+	"github.com/xoctopus/typx/internal/typx"
+)
+
+// Instantiate instantiates generic type or generic function signature with type
+// arguments args. args are positional and can be partial, the nil or missing
+// arguments will be inferred by the core types of type parameter constraints.
+// the type arguments are validated against their constraints. args can be
+// created from reflect.Type, they will be bridged to types.Type.
+func Instantiate(generic Type, args ...Type) (Type, error) {
+	return Infer(generic, nil, args...)
+}
+
+// Infer is like Instantiate, but it infers the missing type arguments from a
+// sample type which has the same shape as the underlying of generic, eg:
+// Infer(ContainsFunc[S ~[]E, E any], func([]int, func(int) bool) bool) infers
+// S=[]int and E=int
+func Infer(generic Type, sample Type, args ...Type) (Type, error) {
+	g, tparams := uninstantiated(generic)
+	if g == nil {
+		return nil, fmt.Errorf("%s is not a generic type", generic)
+	}
+
+	targs := make([]types.Type, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			continue
+		}
+		x, err := bridge(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate %s: %w", generic, err)
+		}
+		targs[i] = x
+	}
+
+	var s types.Type
+	if sample != nil {
+		x, err := bridge(sample)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate %s: %w", generic, err)
+		}
+		s = x
+	}
+
+	targs, err := typx.Infer(tparams, targs, g.Underlying(), s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate %s: %w", generic, err)
+	}
+
+	inst, err := types.Instantiate(nil, g, targs, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate %s: %w", generic, err)
+	}
+	return NewTType(inst), nil
+}
+
+// uninstantiated returns the generic types.Type and its type parameters if t is
+// an uninstantiated generic type or generic function signature.
+func uninstantiated(t Type) (types.Type, *types.TypeParamList) {
+	switch x := t.Unwrap().(type) {
+	case *types.Named:
+		if x.TypeParams().Len() > 0 && x.TypeArgs().Len() == 0 {
+			return x, x.TypeParams()
+		}
+	case *types.Signature:
+		if x.TypeParams().Len() > 0 {
+			return x, x.TypeParams()
+		}
+	}
+	return nil, nil
+}
+
+// bridge returns types.Type of t, reflect.Type will be bridged by package
+// scanning. it returns error if reflect.Type cannot be loaded, eg: the types
+// declared in function scope
+func bridge(t Type) (types.Type, error) {
+	switch x := t.Unwrap().(type) {
+	case types.Type:
+		return x, nil
+	default:
+		return typx.TryNewTTByRT(x.(reflect.Type))
+	}
+}
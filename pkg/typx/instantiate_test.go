@@ -0,0 +1,91 @@
+package typx_test
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestInstantiate(t *testing.T) {
+	pkg := typi.Load(path)
+	generic := func(name string) typx.Type {
+		return typx.NewTType(typi.Lookup[*types.Named](pkg, name))
+	}
+
+	t.Run("Generic", func(t *testing.T) {
+		g := generic("ContainsFunc")
+		Expect(t, g.String(), Equal(path+".ContainsFunc[S,E]"))
+		Expect(t, g.Kind(), Equal(reflect.Func))
+	})
+
+	t.Run("TypeArgs", func(t *testing.T) {
+		expect := reflect.TypeFor[testdata.Serialized[[]byte]]()
+		x, err := typx.Instantiate(generic("Serialized"), typx.NewTType(types.NewSlice(types.Typ[types.Byte])))
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(typx.NewRType(expect).String()))
+
+		// bridges reflect.Type
+		x, err = typx.Instantiate(generic("Serialized"), typx.NewRType(reflect.TypeFor[[]byte]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(typx.NewRType(expect).String()))
+		Expect(t, x.NumMethod(), Equal(expect.NumMethod()))
+	})
+
+	t.Run("InferByCoreType", func(t *testing.T) {
+		expect := typx.NewRType(reflect.TypeFor[testdata.ContainsFunc[[]int, int]]())
+		x, err := typx.Instantiate(generic("ContainsFunc"), typx.NewRType(reflect.TypeFor[[]int]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(expect.String()))
+
+		x, err = typx.Instantiate(generic("ContainsFunc"), nil, typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(expect.String()))
+	})
+
+	t.Run("InferBySample", func(t *testing.T) {
+		expect := typx.NewRType(reflect.TypeFor[testdata.ContainsFunc[[]string, string]]())
+		sample := typx.NewRType(reflect.TypeFor[func([]string, func(string) bool) bool]())
+		x, err := typx.Infer(generic("ContainsFunc"), sample)
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(expect.String()))
+
+		sample = typx.NewRType(reflect.TypeFor[testdata.TypedMap[string, int]]())
+		x, err = typx.Infer(generic("TypedMap"), sample)
+		Expect(t, err, BeNil[error]())
+		Expect(t, x.String(), Equal(sample.String()))
+
+		sample = typx.NewRType(reflect.TypeFor[func([]string, func(int) bool) bool]())
+		_, err = typx.Infer(generic("ContainsFunc"), sample)
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		// not a generic type
+		_, err := typx.Instantiate(typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+		// too many type arguments
+		_, err = typx.Instantiate(generic("Max"), typx.NewRType(reflect.TypeFor[int]()), typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+		// cannot infer
+		_, err = typx.Instantiate(generic("TypedMap"), typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+		// does not satisfy constraint
+		_, err = typx.Instantiate(generic("Serialized"), typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+		// does not satisfy core type
+		_, err = typx.Instantiate(generic("ContainsFunc"), typx.NewRType(reflect.TypeFor[map[int]int]()))
+		Expect(t, err, NotBeNil[error]())
+		// declared in function scope cannot be bridged to go/types
+		type declared []byte
+		_, err = typx.Instantiate(generic("Serialized"), typx.NewRType(reflect.TypeFor[declared]()))
+		Expect(t, err, NotBeNil[error]())
+		_, err = typx.Infer(generic("ContainsFunc"), typx.NewRType(reflect.TypeFor[func(declared, func(byte) bool) bool]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+}
@@ -0,0 +1,172 @@
+package typx
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"go/types"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/xoctopus/x/contextx"
+	"github.com/xoctopus/x/misc/must"
+)
+
+// CtxGOARCH configures the target architecture for memory layout of static
+// types. runtime.GOARCH is used by default. reflect types always use the
+// running architecture.
+var CtxGOARCH = contextx.NewT[string]()
+
+func sizes(ctx context.Context) types.Sizes {
+	arch := runtime.GOARCH
+	if ctx != nil {
+		if x, ok := CtxGOARCH.From(ctx); ok {
+			arch = x
+		}
+	}
+	s := types.SizesFor("gc", arch)
+	must.BeTrueF(s != nil, "unsupported GOARCH: %s", arch)
+	return s
+}
+
+// NewLayout returns memory layout of struct type t and suggests a field order
+// minimizing paddings.
+func NewLayout(t Type) (*Layout, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expect a struct type, but got %s", t)
+	}
+
+	l := &Layout{
+		Type:   t,
+		Size:   t.Size(),
+		Align:  t.Align(),
+		Fields: make([]*FieldLayout, t.NumField()),
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		ft := f.Type()
+		l.Fields[i] = &FieldLayout{
+			Name:   f.Name(),
+			Type:   ft,
+			Offset: f.Offset(),
+			Size:   ft.Size(),
+			Align:  ft.FieldAlign(),
+		}
+	}
+	l.Padding = pad(l.Fields, l.Size)
+
+	l.Optimal = make([]*FieldLayout, len(l.Fields))
+	for i, f := range l.Fields {
+		x := *f
+		l.Optimal[i] = &x
+	}
+	slices.SortStableFunc(l.Optimal, func(a, b *FieldLayout) int {
+		// zero-sized fields first, a trailing zero-sized field takes padding
+		if za, zb := a.Size == 0, b.Size == 0; za != zb {
+			if za {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(b.Align, a.Align); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Size, a.Size)
+	})
+	l.OptimalSize = arrange(l.Optimal, l.Align)
+	return l, nil
+}
+
+// Layout describes memory layout of a struct type
+type Layout struct {
+	Type  Type
+	Size  uintptr
+	Align int
+	// Fields in declaration order
+	Fields []*FieldLayout
+	// Padding total padding bytes
+	Padding uintptr
+	// Optimal fields in suggested order
+	Optimal []*FieldLayout
+	// OptimalSize struct size in suggested order
+	OptimalSize uintptr
+}
+
+// Reorderable reports if the struct size can be reduced by reordering fields
+func (l *Layout) Reorderable() bool {
+	return l.OptimalSize < l.Size
+}
+
+// String returns layout report
+func (l *Layout) String() string {
+	b := strings.Builder{}
+	_, _ = fmt.Fprintf(&b, "%s: size=%d align=%d padding=%d\n", l.Type, l.Size, l.Align, l.Padding)
+	for _, f := range l.Fields {
+		_, _ = fmt.Fprintf(&b, "\t%s\n", f)
+	}
+	if l.Reorderable() {
+		names := make([]string, len(l.Optimal))
+		for i, f := range l.Optimal {
+			names[i] = f.Name
+		}
+		_, _ = fmt.Fprintf(&b, "suggested order: size=%d: %s\n", l.OptimalSize, strings.Join(names, ", "))
+	}
+	return b.String()
+}
+
+// FieldLayout describes memory layout of a struct field
+type FieldLayout struct {
+	Name   string
+	Type   Type
+	Offset uintptr
+	Size   uintptr
+	Align  int
+	// Padding bytes after this field
+	Padding uintptr
+}
+
+func (f *FieldLayout) String() string {
+	return fmt.Sprintf(
+		"%s %s: offset=%d size=%d align=%d padding=%d",
+		f.Name, f.Type, f.Offset, f.Size, f.Align, f.Padding,
+	)
+}
+
+// arrange computes field offsets in order and returns the struct size
+func arrange(fields []*FieldLayout, align int) uintptr {
+	offset := uintptr(0)
+	for _, f := range fields {
+		offset = roundup(offset, uintptr(f.Align))
+		f.Offset = offset
+		offset += f.Size
+	}
+	if n := len(fields); n > 0 && fields[n-1].Size == 0 && offset > 0 {
+		// gc pads a trailing zero-sized field to avoid pointing past the object
+		offset++
+	}
+	size := roundup(offset, uintptr(align))
+	pad(fields, size)
+	return size
+}
+
+// pad computes paddings after each field and returns total paddings
+func pad(fields []*FieldLayout, size uintptr) (total uintptr) {
+	for i, f := range fields {
+		next := size
+		if i < len(fields)-1 {
+			next = fields[i+1].Offset
+		}
+		f.Padding = next - f.Offset - f.Size
+		total += f.Padding
+	}
+	return total
+}
+
+func roundup(x, align uintptr) uintptr {
+	if align <= 1 {
+		return x
+	}
+	return (x + align - 1) / align * align
+}
@@ -0,0 +1,96 @@
+package typx_test
+
+import (
+	"context"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestLayout(t *testing.T) {
+	t.Run("SizeAndAlign", func(t *testing.T) {
+		for _, r := range []reflect.Type{
+			reflect.TypeFor[testdata.Padded](),
+			reflect.TypeFor[testdata.Tagged](),
+			reflect.TypeFor[testdata.BTreeNode[int]](),
+			reflect.TypeFor[testdata.Serialized[[]byte]](),
+			reflect.TypeFor[[3]testdata.String](),
+			reflect.TypeFor[map[int]string](),
+			reflect.TypeFor[func()](),
+			reflect.TypeFor[any](),
+		} {
+			rt := typx.NewRType(r)
+			tt := typx.NewTType(typi.NewTTByRT(r))
+			Expect(t, tt.Size(), Equal(rt.Size()))
+			Expect(t, tt.Align(), Equal(rt.Align()))
+			Expect(t, tt.FieldAlign(), Equal(rt.FieldAlign()))
+			for i := range rt.NumField() {
+				Expect(t, tt.Field(i).Offset(), Equal(rt.Field(i).Offset()))
+			}
+		}
+	})
+
+	t.Run("PromotedField", func(t *testing.T) {
+		r := reflect.TypeFor[testdata.Embedded]()
+		rf, _ := typx.NewRType(r).FieldByName("B")
+		tf, _ := typx.NewTType(typi.NewTTByRT(r)).FieldByName("B")
+		Expect(t, tf.Offset(), Equal(rf.Offset()))
+		Expect(t, tf.Offset() > 0, BeTrue())
+	})
+
+	t.Run("GOARCH", func(t *testing.T) {
+		ctx := typx.CtxGOARCH.With(context.Background(), "386")
+		tt := typx.NewTTypeContext(ctx, typi.NewTTByRT(reflect.TypeFor[testdata.Padded]()))
+		Expect(t, tt.Size(), Equal(uintptr(24)))
+		Expect(t, tt.Align(), Equal(4))
+		Expect(t, tt.Field(1).Offset(), Equal(uintptr(4)))
+		Expect(t, tt.Field(1).Type().Align(), Equal(4))
+
+		ctx = typx.CtxGOARCH.With(context.Background(), "amd64")
+		tt = typx.NewTTypeContext(ctx, tt.Unwrap().(types.Type))
+		Expect(t, tt.Size(), Equal(uintptr(32)))
+		Expect(t, tt.Field(1).Offset(), Equal(uintptr(8)))
+
+		ctx = typx.CtxGOARCH.With(context.Background(), "unknown")
+		tt = typx.NewTTypeContext(ctx, tt.Unwrap().(types.Type))
+		ExpectPanic[error](t, func() { tt.Size() })
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		ctx := typx.CtxGOARCH.With(context.Background(), "amd64")
+		tt := typx.NewTTypeContext(ctx, typi.NewTTByRT(reflect.TypeFor[testdata.Padded]()))
+
+		l, err := typx.NewLayout(tt)
+		Expect(t, err, BeNil[error]())
+		Expect(t, l.Size, Equal(uintptr(32)))
+		Expect(t, l.Align, Equal(8))
+		Expect(t, l.Padding, Equal(uintptr(32-1-8-1-4)))
+		Expect(t, l.Fields[0].Padding, Equal(uintptr(7)))
+		Expect(t, l.Fields[4].Padding, Equal(uintptr(8)))
+		Expect(t, l.Reorderable(), BeTrue())
+		Expect(t, l.OptimalSize, Equal(uintptr(16)))
+
+		names := make([]string, 0)
+		for _, f := range l.Optimal {
+			names = append(names, f.Name)
+		}
+		Expect(t, names, Equal([]string{"E", "B", "D", "A", "C"}))
+		Expect(t, strings.Contains(l.String(), "suggested order: size=16: E, B, D, A, C"), BeTrue())
+
+		l, err = typx.NewLayout(typx.NewRType(reflect.TypeFor[testdata.BTreeNode[int]]()))
+		Expect(t, err, BeNil[error]())
+		Expect(t, l.Reorderable(), BeFalse())
+		Expect(t, l.Padding, Equal(uintptr(0)))
+		Expect(t, strings.Contains(l.String(), "suggested"), BeFalse())
+
+		_, err = typx.NewLayout(typx.NewRType(reflect.TypeFor[int]()))
+		Expect(t, err, NotBeNil[error]())
+	})
+}
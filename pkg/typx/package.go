@@ -0,0 +1,274 @@
+package typx
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/xoctopus/x/misc/must"
+
+	"github.com/xoctopus/typx/internal/typx"
+)
+
+// Load loads package by import path. `_test` suffixed path loads the external
+// test package.
+func Load(path string) (*Package, error) {
+	p, err := typx.LoadPackage(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPackage(p.Types), nil
+}
+
+func NewPackage(p *types.Package) *Package {
+	must.NotNilF(p, "invalid types.Package")
+	return &Package{p: p}
+}
+
+// Package wraps a loaded package for inspecting its members
+type Package struct {
+	p *types.Package
+}
+
+func (p *Package) Unwrap() *types.Package {
+	return p.p
+}
+
+func (p *Package) Path() string {
+	return p.p.Path()
+}
+
+func (p *Package) Name() string {
+	return p.p.Name()
+}
+
+// Filter filters package members. the zero Filter matches all exported members.
+type Filter struct {
+	// Unexported includes unexported members
+	Unexported bool
+	// Aliases includes type aliases, they are listed as their aliased types
+	Aliases bool
+	// Kinds matches members by kind of their types, empty matches any kind
+	Kinds []reflect.Kind
+	// Pattern matches members by name in glob syntax, see path.Match
+	Pattern string
+	// Type matches constants and variables of this type
+	Type Type
+}
+
+func (f *Filter) match(name string, t func() Type) bool {
+	if !f.Unexported && !token.IsExported(name) {
+		return false
+	}
+	if f.Pattern != "" {
+		if matched, _ := path.Match(f.Pattern, name); !matched {
+			return false
+		}
+	}
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, t().Kind()) {
+		return false
+	}
+	if f.Type != nil && !Identical(t(), f.Type) {
+		return false
+	}
+	return true
+}
+
+func (p *Package) objects(f Filter, match func(types.Object) bool) []types.Object {
+	objects := make([]types.Object, 0)
+	for _, name := range p.p.Scope().Names() {
+		if obj := p.p.Scope().Lookup(name); match(obj) {
+			objects = append(objects, obj)
+		}
+	}
+	return slices.DeleteFunc(objects, func(obj types.Object) bool {
+		return !f.match(obj.Name(), func() Type { return typeOf(obj) })
+	})
+}
+
+// Types returns named types declared in package, generic types are listed
+// uninstantiated.
+func (p *Package) Types(f Filter) []Type {
+	objects := p.objects(f, func(obj types.Object) bool {
+		x, ok := obj.(*types.TypeName)
+		return ok && (!x.IsAlias() || f.Aliases)
+	})
+	typs := make([]Type, len(objects))
+	for i, obj := range objects {
+		typs[i] = NewTType(obj.Type())
+	}
+	return typs
+}
+
+// Funcs returns package level functions
+func (p *Package) Funcs(f Filter) []*Func {
+	objects := p.objects(f, func(obj types.Object) bool {
+		_, ok := obj.(*types.Func)
+		return ok
+	})
+	funcs := make([]*Func, len(objects))
+	for i, obj := range objects {
+		funcs[i] = NewFunc(obj.(*types.Func))
+	}
+	return funcs
+}
+
+// Consts returns package level constants
+func (p *Package) Consts(f Filter) []*Const {
+	objects := p.objects(f, func(obj types.Object) bool {
+		_, ok := obj.(*types.Const)
+		return ok
+	})
+	consts := make([]*Const, len(objects))
+	for i, obj := range objects {
+		consts[i] = &Const{c: obj.(*types.Const)}
+	}
+	return consts
+}
+
+// Vars returns package level variables
+func (p *Package) Vars(f Filter) []*Var {
+	objects := p.objects(f, func(obj types.Object) bool {
+		_, ok := obj.(*types.Var)
+		return ok
+	})
+	vars := make([]*Var, len(objects))
+	for i, obj := range objects {
+		vars[i] = &Var{v: obj.(*types.Var)}
+	}
+	return vars
+}
+
+// Type lookups type by name
+func (p *Package) Type(name string) (Type, bool) {
+	if x, ok := p.p.Scope().Lookup(name).(*types.TypeName); ok {
+		return NewTType(x.Type()), true
+	}
+	return nil, false
+}
+
+// Func lookups function by name, method should be named as `Recv.Method`
+func (p *Package) Func(name string) (*Func, bool) {
+	recv, method, ok := strings.Cut(name, ".")
+	if !ok {
+		if f, ok := p.p.Scope().Lookup(name).(*types.Func); ok {
+			return NewFunc(f), true
+		}
+		return nil, false
+	}
+
+	if x, ok := p.p.Scope().Lookup(recv).(*types.TypeName); ok {
+		if n, ok := types.Unalias(x.Type()).(*types.Named); ok {
+			for i := range n.NumMethods() {
+				if m := n.Method(i); m.Name() == method {
+					return NewFunc(m), true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Const lookups constant by name
+func (p *Package) Const(name string) (*Const, bool) {
+	if c, ok := p.p.Scope().Lookup(name).(*types.Const); ok {
+		return &Const{c: c}, true
+	}
+	return nil, false
+}
+
+// Var lookups variable by name
+func (p *Package) Var(name string) (*Var, bool) {
+	if v, ok := p.p.Scope().Lookup(name).(*types.Var); ok {
+		return &Var{v: v}, true
+	}
+	return nil, false
+}
+
+// Const describes a package level constant
+type Const struct {
+	c *types.Const
+}
+
+func (c *Const) Unwrap() *types.Const {
+	return c.c
+}
+
+func (c *Const) Name() string {
+	return c.c.Name()
+}
+
+func (c *Const) Exported() bool {
+	return c.c.Exported()
+}
+
+// Type returns constant's type, untyped constant returns its default type
+func (c *Const) Type() Type {
+	return typeOf(c.c)
+}
+
+func (c *Const) Value() constant.Value {
+	return c.c.Val()
+}
+
+func (c *Const) Position() token.Position {
+	return typx.Position(c.c)
+}
+
+// Doc returns doc comment of constant declaration
+func (c *Const) Doc() string {
+	doc, _ := typx.Doc(c.c)
+	return doc
+}
+
+// Comment returns line comment of constant declaration
+func (c *Const) Comment() string {
+	_, comment := typx.Doc(c.c)
+	return comment
+}
+
+// Var describes a package level variable
+type Var struct {
+	v *types.Var
+}
+
+func (v *Var) Unwrap() *types.Var {
+	return v.v
+}
+
+func (v *Var) Name() string {
+	return v.v.Name()
+}
+
+func (v *Var) Exported() bool {
+	return v.v.Exported()
+}
+
+func (v *Var) Type() Type {
+	return typeOf(v.v)
+}
+
+func (v *Var) Position() token.Position {
+	return typx.Position(v.v)
+}
+
+// Doc returns doc comment of variable declaration
+func (v *Var) Doc() string {
+	doc, _ := typx.Doc(v.v)
+	return doc
+}
+
+// Comment returns line comment of variable declaration
+func (v *Var) Comment() string {
+	_, comment := typx.Doc(v.v)
+	return comment
+}
+
+// typeOf returns Type of obj, untyped constant uses its default type
+func typeOf(obj types.Object) Type {
+	return NewTType(types.Default(obj.Type()))
+}
@@ -0,0 +1,115 @@
+package typx_test
+
+import (
+	"go/constant"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestPackage(t *testing.T) {
+	p, err := typx.Load(path)
+	Expect(t, err, BeNil[error]())
+	Expect(t, p.Path(), Equal(path))
+	Expect(t, p.Name(), Equal("testdata"))
+	Expect(t, p.Unwrap().Path(), Equal(path))
+
+	_, err = typx.Load(path + "/not/found")
+	Expect(t, err, NotBeNil[error]())
+
+	names := func(vs []typx.Type) []string {
+		s := make([]string, len(vs))
+		for i, v := range vs {
+			s[i] = v.Name()
+		}
+		return s
+	}
+
+	t.Run("Types", func(t *testing.T) {
+		all := p.Types(typx.Filter{})
+		for _, v := range all {
+			Expect(t, v.PkgPath(), Equal(path))
+			Expect(t, strings.HasPrefix(v.Name(), "_"), BeFalse())
+		}
+		Expect(t, len(p.Types(typx.Filter{Unexported: true})) > len(all), BeTrue())
+		Expect(t, len(p.Types(typx.Filter{Aliases: true})) > len(all), BeTrue())
+
+		Expect(t, names(p.Types(typx.Filter{Pattern: "StringerL*"})), Equal([]string{
+			"StringerL1", "StringerL2", "StringerL2WrapL1", "StringerL3", "StringerL3WrapL2",
+		}))
+		Expect(t, names(p.Types(typx.Filter{Pattern: "*Array", Kinds: []reflect.Kind{reflect.Slice}})), Equal([]string{}))
+		Expect(t, names(p.Types(typx.Filter{Pattern: "Typed*", Kinds: []reflect.Kind{reflect.Slice}})), Equal([]string{
+			"TypedSlice[T]",
+		}))
+
+		x, ok := p.Type("BTreeNode")
+		Expect(t, ok, BeTrue())
+		Expect(t, x.String(), Equal(path+".BTreeNode[T]"))
+		_, ok = p.Type("Sum")
+		Expect(t, ok, BeFalse())
+	})
+
+	t.Run("Funcs", func(t *testing.T) {
+		funcs := p.Funcs(typx.Filter{Pattern: "[AS]*"})
+		Expect(t, len(funcs), Equal(2))
+		Expect(t, funcs[0].Name(), Equal("Apply"))
+		Expect(t, funcs[1].Name(), Equal("Sum"))
+
+		_, ok := p.Func("Sum")
+		Expect(t, ok, BeTrue())
+		_, ok = p.Func("Int")
+		Expect(t, ok, BeFalse())
+		_, ok = p.Func("BTreeNode.InsertL")
+		Expect(t, ok, BeTrue())
+		_, ok = p.Func("Sum.Any")
+		Expect(t, ok, BeFalse())
+	})
+
+	t.Run("Consts", func(t *testing.T) {
+		consts := p.Consts(typx.Filter{Pattern: "SIZE"})
+		Expect(t, len(consts), Equal(1))
+		c := consts[0]
+		Expect(t, c.Name(), Equal("SIZE"))
+		Expect(t, c.Exported(), BeTrue())
+		Expect(t, c.Type().String(), Equal("int"))
+		Expect(t, constant.Compare(c.Value(), token.EQL, constant.MakeInt64(32)), BeTrue())
+		Expect(t, c.Position().Line > 0, BeTrue())
+		Expect(t, c.Unwrap().Name(), Equal("SIZE"))
+
+		_, ok := p.Const("SIZE")
+		Expect(t, ok, BeTrue())
+		_, ok = p.Const("Int")
+		Expect(t, ok, BeFalse())
+	})
+
+	t.Run("Vars", func(t *testing.T) {
+		vars := p.Vars(typx.Filter{Pattern: "Default*"})
+		Expect(t, len(vars), Equal(1))
+		v := vars[0]
+		Expect(t, v.Name(), Equal("DefaultString"))
+		Expect(t, v.Exported(), BeTrue())
+		Expect(t, v.Type().String(), Equal(path+".String"))
+		Expect(t, v.Position().Line > 0, BeTrue())
+		Expect(t, v.Unwrap().Name(), Equal("DefaultString"))
+
+		vars = p.Vars(typx.Filter{Unexported: true, Type: typx.NewRType(reflect.TypeFor[testdata.Error]())})
+		Expect(t, len(vars), Equal(1))
+		Expect(t, vars[0].Name(), Equal("defaultError"))
+
+		// alias type is identical to its aliased type
+		vars = p.Vars(typx.Filter{Unexported: true, Type: typx.NewRType(reflect.TypeFor[testdata.Int]())})
+		Expect(t, len(vars), Equal(1))
+		Expect(t, vars[0].Name(), Equal("aliasedInt"))
+
+		_, ok := p.Var("DefaultString")
+		Expect(t, ok, BeTrue())
+		_, ok = p.Var("SIZE")
+		Expect(t, ok, BeFalse())
+	})
+}
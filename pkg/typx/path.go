@@ -0,0 +1,262 @@
+package typx
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xoctopus/typx/internal/jsonfields"
+)
+
+// PathError describes why a path expression failed to parse or resolve
+type PathError struct {
+	// Path is the path expression
+	Path string
+	// Offset of the failed segment in path
+	Offset int
+	// Type is the type which the failed segment is resolved against, it is nil
+	// if path failed to parse
+	Type Type
+	Err  string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("invalid path %q at %d: %s", e.Path, e.Offset, e.Err)
+}
+
+// pathStep is a segment of path, it is either a field name or the raw literal
+// in brackets
+type pathStep struct {
+	field   string
+	literal string
+	offset  int
+}
+
+// parsePath parses path like `a.b[3].c["k"]` and `Spec.Containers[].Image`,
+// the literal in brackets is empty for `[]`.
+func parsePath(path string) ([]pathStep, error) {
+	fail := func(offset int, format string, args ...any) error {
+		return &PathError{Path: path, Offset: offset, Err: fmt.Sprintf(format, args...)}
+	}
+
+	steps := make([]pathStep, 0)
+	for i := 0; i < len(path); {
+		if path[i] == '[' {
+			end := strings.IndexByte(path[i:], ']')
+			if strings.HasPrefix(path[i+1:], `"`) {
+				quoted, err := strconv.QuotedPrefix(path[i+1:])
+				if err != nil {
+					return nil, fail(i+1, "unterminated quoted key")
+				}
+				end = 1 + len(quoted)
+				if !strings.HasPrefix(path[i+end:], "]") {
+					return nil, fail(i+end, "expect `]`")
+				}
+			}
+			if end < 0 {
+				return nil, fail(i, "unclosed bracket")
+			}
+			steps = append(steps, pathStep{literal: path[i+1 : i+end], offset: i})
+			i += end + 1
+			continue
+		}
+
+		if i > 0 {
+			if path[i] != '.' {
+				return nil, fail(i, "unexpected %q", path[i])
+			}
+			i++
+		}
+		end := i
+		for end < len(path) && path[end] != '.' && path[end] != '[' {
+			end++
+		}
+		if end == i {
+			return nil, fail(i, "expect field name")
+		}
+		steps = append(steps, pathStep{field: path[i:end], offset: i})
+		i = end
+	}
+	return steps, nil
+}
+
+// ResolvePath resolves path expression against t by Go field names, see
+// PathResolver.Resolve
+func ResolvePath(t Type, path string) (Type, []Step, error) {
+	return PathResolver{}.Resolve(t, path)
+}
+
+// PathResolver resolves path expressions against types. the zero PathResolver
+// selects fields by Go names.
+type PathResolver struct {
+	// JSON selects fields by json names, the embedded structs without json
+	// name are flattened and the dominant field is selected as encoding/json
+	// does.
+	JSON bool
+}
+
+// Resolve resolves path expression like `Spec.Containers[].Image` against t.
+// fields are selected through embedded structs as promoted, `[]` selects the
+// element of slice, array or map, and the index or key in brackets, eg: `[3]`
+// or `["k"]`, is validated against the indexed type. pointers are dereferenced
+// implicitly. it returns the terminal type and the steps from t, which are
+// consistent with Cursor.Path in Walk: dereference and element are StepElem,
+// and field is StepField. the error is a *PathError.
+func (r PathResolver) Resolve(t Type, path string) (Type, []Step, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	steps := make([]Step, 0)
+	for _, s := range segments {
+		fail := func(format string, args ...any) error {
+			return &PathError{Path: path, Offset: s.offset, Type: t, Err: fmt.Sprintf(format, args...)}
+		}
+
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+			steps = append(steps, Step{Kind: StepElem})
+		}
+
+		if s.field != "" {
+			if t.Kind() != reflect.Struct {
+				return nil, nil, fail("cannot select field %s of non-struct type %s", s.field, t)
+			}
+			selected, ft, err := r.field(t, s.field)
+			if err != nil {
+				return nil, nil, fail("%s", err)
+			}
+			steps = append(steps, selected...)
+			t = ft
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Array, reflect.Slice:
+			if s.literal != "" {
+				i, err := strconv.Atoi(s.literal)
+				if err != nil || i < 0 {
+					return nil, nil, fail("invalid index %s of %s", s.literal, t)
+				}
+				if t.Kind() == reflect.Array && i >= t.Len() {
+					return nil, nil, fail("index %d out of range of %s", i, t)
+				}
+			}
+		case reflect.Map:
+			if s.literal != "" && !validKey(t.Key(), s.literal) {
+				return nil, nil, fail("invalid key %s of %s", s.literal, t)
+			}
+		default:
+			return nil, nil, fail("cannot index non-indexable type %s", t)
+		}
+		t = t.Elem()
+		steps = append(steps, Step{Kind: StepElem})
+	}
+	return t, steps, nil
+}
+
+// validKey reports if literal is a valid key of type t
+func validKey(t Type, literal string) bool {
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		_, err = strconv.Unquote(literal)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(literal, 10, int(t.Size())*8)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		_, err = strconv.ParseUint(literal, 10, int(t.Size())*8)
+	case reflect.Bool:
+		_, err = strconv.ParseBool(literal)
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// field selects field by name in struct t with promotion, it returns the steps
+// from t and the type of selected field
+func (r PathResolver) field(t Type, name string) ([]Step, Type, error) {
+	if r.JSON {
+		return jsonField(t, name)
+	}
+
+	type candidate struct {
+		steps []Step
+		t     Type
+	}
+
+	var (
+		current []candidate
+		next    = []candidate{{t: t}}
+		visited = make(map[string]bool)
+	)
+	for len(next) > 0 {
+		current, next = next, nil
+		matched := make([]candidate, 0)
+		for _, c := range current {
+			visited[c.t.String()] = true
+		}
+		for _, c := range current {
+			for i := range c.t.NumField() {
+				f := c.t.Field(i)
+				steps := append(slices.Clone(c.steps), Step{Kind: StepField, Index: i, Name: f.Name()})
+				ft := f.Type()
+				if f.Name() == name {
+					matched = append(matched, candidate{steps: steps, t: ft})
+				}
+				// embedded struct to be flattened
+				if f.Anonymous() && Deref(ft).Kind() == reflect.Struct && !visited[Deref(ft).String()] {
+					embedded := candidate{steps: steps, t: Deref(ft)}
+					if ft.Kind() == reflect.Pointer {
+						embedded.steps = append(slices.Clone(steps), Step{Kind: StepElem})
+					}
+					next = append(next, embedded)
+				}
+			}
+		}
+
+		switch len(matched) {
+		case 0:
+			continue
+		case 1:
+			return matched[0].steps, matched[0].t, nil
+		default:
+			return nil, nil, fmt.Errorf("ambiguous field %s in %s", name, t)
+		}
+	}
+	return nil, nil, fmt.Errorf("no field %s in %s", name, t)
+}
+
+// jsonField selects field by json name in struct t as encoding/json does
+func jsonField(t Type, name string) ([]Step, Type, error) {
+	fields := jsonfields.Fields(t)
+	i := slices.IndexFunc(fields, func(f jsonfields.Field[Type]) bool { return f.Name == name })
+	if i < 0 {
+		if slices.ContainsFunc(jsonfields.All(t), func(f jsonfields.Field[Type]) bool { return f.Name == name }) {
+			return nil, nil, fmt.Errorf("ambiguous field %s in %s", name, t)
+		}
+		return nil, nil, fmt.Errorf("no field %s in %s", name, t)
+	}
+
+	var (
+		steps = make([]Step, 0)
+		x     = t
+		ft    Type
+	)
+	for _, index := range fields[i].Index {
+		if ft != nil {
+			// embedded struct
+			if ft.Kind() == reflect.Pointer {
+				steps = append(steps, Step{Kind: StepElem})
+			}
+			x = Deref(ft)
+		}
+		f := x.Field(index)
+		steps = append(steps, Step{Kind: StepField, Index: index, Name: f.Name()})
+		ft = f.Type()
+	}
+	return steps, ft, nil
+}
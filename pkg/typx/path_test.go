@@ -0,0 +1,103 @@
+package typx_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestResolvePath(t *testing.T) {
+	rt := reflect.TypeFor[testdata.Deployment]()
+	for _, x := range []typx.Type{typx.NewRType(rt), typx.NewTType(typi.NewTTByRT(rt))} {
+		t.Run("GoNames", func(t *testing.T) {
+			for path, expect := range map[string]struct {
+				t     string
+				steps string
+			}{
+				"Name":                         {"string", "Field(ObjectMeta).Field(Name)"},
+				"ObjectMeta.Labels[\"app\"]":   {"string", "Field(ObjectMeta).Field(Labels).Elem"},
+				"Spec.Containers[].Image":      {"string", "Field(Spec).Elem.Field(Containers).Elem.Field(Image)"},
+				"Spec.Containers[3].Ports[80]": {"int", "Field(Spec).Elem.Field(Containers).Elem.Field(Ports).Elem"},
+				"Spec.Sidecars[1].Args[]":      {"string", "Field(Spec).Elem.Field(Sidecars).Elem.Field(Args).Elem"},
+				"Spec.Selector[].Image":        {"string", "Field(Spec).Elem.Field(Selector).Elem.Elem.Field(Image)"},
+				"Spec.Replicas":                {"*github.com/xoctopus/typx/testdata.Replicas", "Field(Spec).Elem.Field(Replicas)"},
+				"Spec.Replicas.Replicas":       {"int32", "Field(Spec).Elem.Field(Replicas).Elem.Field(Replicas)"},
+				"Spec.Limits.CPU":              {"string", "Field(Spec).Elem.Field(Limits).Field(CPU)"},
+				"Spec.Containers[].Probe":      {"interface {}", "Field(Spec).Elem.Field(Containers).Elem.Field(Probe)"},
+				"":                             {"github.com/xoctopus/typx/testdata.Deployment", ""},
+			} {
+				terminal, steps, err := typx.ResolvePath(x, path)
+				Expect(t, err, BeNil[error]())
+				Expect(t, terminal.String(), Equal(expect.t))
+				Expect(t, stepsString(steps), Equal(expect.steps))
+			}
+		})
+
+		t.Run("JSONNames", func(t *testing.T) {
+			r := typx.PathResolver{JSON: true}
+			for path, expect := range map[string]struct {
+				t     string
+				steps string
+			}{
+				"metadata.name":           {"string", "Field(ObjectMeta).Field(Name)"},
+				"spec.containers[].image": {"string", "Field(Spec).Elem.Field(Containers).Elem.Field(Image)"},
+				"spec.replicas":           {"int32", "Field(Spec).Elem.Field(Replicas).Elem.Field(Replicas)"},
+				"spec.cpu":                {"string", "Field(Spec).Elem.Field(Resources).Field(CPU)"},
+				"spec.maxCPU":             {"string", "Field(Spec).Elem.Field(Limits).Field(CPU)"},
+				"spec.Memory":             {"string", "Field(Spec).Elem.Field(Limits).Field(Memory)"},
+			} {
+				terminal, steps, err := r.Resolve(x, path)
+				Expect(t, err, BeNil[error]())
+				Expect(t, terminal.String(), Equal(expect.t))
+				Expect(t, stepsString(steps), Equal(expect.steps))
+			}
+
+			for _, path := range []string{"Name", "metadata.Name", "spec.Resources", "spec.CPU"} {
+				_, _, err := r.Resolve(x, path)
+				Expect(t, err, NotBeNil[error]())
+			}
+		})
+
+		t.Run("Errors", func(t *testing.T) {
+			for path, expect := range map[string]string{
+				"Spec.Containers[].Imag":     `invalid path "Spec.Containers[].Imag" at 18: no field Imag in github.com/xoctopus/typx/testdata.Container`,
+				"Spec.CPU":                   `invalid path "Spec.CPU" at 5: ambiguous field CPU in github.com/xoctopus/typx/testdata.DeploymentSpec`,
+				"Name.First":                 `invalid path "Name.First" at 5: cannot select field First of non-struct type string`,
+				"Name[]":                     `invalid path "Name[]" at 4: cannot index non-indexable type string`,
+				"Spec.Sidecars[2]":           `invalid path "Spec.Sidecars[2]" at 13: index 2 out of range of [2]github.com/xoctopus/typx/testdata.Container`,
+				"Spec.Containers[x]":         `invalid path "Spec.Containers[x]" at 15: invalid index x of []github.com/xoctopus/typx/testdata.Container`,
+				"Spec.Selector[1]":           `invalid path "Spec.Selector[1]" at 13: invalid key 1 of map[string]*github.com/xoctopus/typx/testdata.Container`,
+				"Spec.Containers[].Ports[x]": `invalid path "Spec.Containers[].Ports[x]" at 23: invalid key x of map[int]int`,
+				"Spec.Containers[":           `invalid path "Spec.Containers[" at 15: unclosed bracket`,
+				`Labels["app]`:               `invalid path "Labels[\"app]" at 7: unterminated quoted key`,
+				`Labels["app"x]`:             `invalid path "Labels[\"app\"x]" at 12: expect ` + "`]`",
+				"Spec..Image":                `invalid path "Spec..Image" at 5: expect field name`,
+				"Spec[]x":                    `invalid path "Spec[]x" at 6: unexpected 'x'`,
+			} {
+				_, _, err := typx.ResolvePath(x, path)
+				Expect(t, err, NotBeNil[error]())
+				Expect(t, err.Error(), Equal(expect))
+
+				e := &typx.PathError{}
+				Expect(t, errors.As(err, &e), BeTrue())
+			}
+		})
+	}
+}
+
+func stepsString(steps []typx.Step) string {
+	s := ""
+	for i, step := range steps {
+		if i > 0 {
+			s += "."
+		}
+		s += step.String()
+	}
+	return s
+}
@@ -2,6 +2,7 @@ package typx
 
 import (
 	"context"
+	"go/token"
 	"go/types"
 	"reflect"
 
@@ -115,6 +116,13 @@ func (t *rtype) Len() int {
 	return 0
 }
 
+func (t *rtype) ChanDir() reflect.ChanDir {
+	if t.t.Kind() == reflect.Chan {
+		return t.t.ChanDir()
+	}
+	return 0
+}
+
 func (t *rtype) NumField() int {
 	if t.Kind() == reflect.Struct {
 		return t.t.NumField()
@@ -200,6 +208,19 @@ func (t *rtype) Out(i int) Type {
 	return nil
 }
 
+func (t *rtype) Size() uintptr { return t.t.Size() }
+
+func (t *rtype) Align() int { return t.t.Align() }
+
+func (t *rtype) FieldAlign() int { return t.t.FieldAlign() }
+
+// Position returns zero position, reflect.Type has no source info
+func (t *rtype) Position() token.Position { return token.Position{} }
+
+func (t *rtype) Doc() string { return "" }
+
+func (t *rtype) Comment() string { return "" }
+
 type RStructField struct {
 	ctx context.Context
 	reflect.StructField
@@ -225,6 +246,16 @@ func (f *RStructField) Anonymous() bool {
 	return f.StructField.Anonymous
 }
 
+func (f *RStructField) Offset() uintptr {
+	return f.StructField.Offset
+}
+
+func (f *RStructField) Position() token.Position { return token.Position{} }
+
+func (f *RStructField) Doc() string { return "" }
+
+func (f *RStructField) Comment() string { return "" }
+
 type RMethod struct {
 	ctx context.Context
 	reflect.Method
@@ -241,3 +272,9 @@ func (m *RMethod) Name() string {
 func (m *RMethod) Type() Type {
 	return NewRType(m.Method.Type)
 }
+
+func (m *RMethod) Position() token.Position { return token.Position{} }
+
+func (m *RMethod) Doc() string { return "" }
+
+func (m *RMethod) Comment() string { return "" }
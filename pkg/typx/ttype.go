@@ -3,6 +3,7 @@ package typx
 import (
 	"context"
 	"fmt"
+	"go/token"
 	"go/types"
 	"reflect"
 
@@ -12,6 +13,12 @@ import (
 )
 
 func NewTType(t types.Type) Type {
+	return NewTTypeContext(context.Background(), t)
+}
+
+// NewTTypeContext is like NewTType, ctx is passed to derived types, fields and
+// methods. eg: CtxGOARCH configures the target architecture of memory layout.
+func NewTTypeContext(ctx context.Context, t types.Type) Type {
 	var (
 		xt    types.Type
 		alias *types.Alias
@@ -20,12 +27,13 @@ func NewTType(t types.Type) Type {
 	case *types.Union, *types.Tuple, *types.TypeParam:
 		panic(fmt.Errorf("invalid NewTType by types.Type from `%T`", x))
 	case *types.Alias:
-		xt = x.Rhs()
+		xt = types.Unalias(x)
 		alias = x
 	default:
 		xt = x
 	}
 	return &ttype{
+		ctx:     ctx,
 		methods: typx.InspectMethods(xt),
 		t:       xt,
 		u:       typx.NewLitType(xt),
@@ -68,7 +76,7 @@ func (t *ttype) Kind() reflect.Kind {
 	default:
 		x, ok := t.t.(*types.Named)
 		must.BeTrue(ok)
-		return NewTType(x.Underlying()).Kind()
+		return NewTTypeContext(t.ctx, x.Underlying()).Kind()
 	}
 }
 
@@ -139,9 +147,9 @@ func (t *ttype) Comparable() bool {
 func (t *ttype) Key() Type {
 	switch x := t.t.(type) {
 	case interface{ Key() types.Type }:
-		return NewTType(x.Key())
+		return NewTTypeContext(t.ctx, x.Key())
 	case *types.Named:
-		return NewTType(typx.Underlying(x)).Key()
+		return NewTTypeContext(t.ctx, typx.Underlying(x)).Key()
 	default:
 		return nil
 	}
@@ -150,9 +158,9 @@ func (t *ttype) Key() Type {
 func (t *ttype) Elem() Type {
 	switch x := t.t.(type) {
 	case interface{ Elem() types.Type }:
-		return NewTType(x.Elem())
+		return NewTTypeContext(t.ctx, x.Elem())
 	case *types.Named:
-		return NewTType(typx.Underlying(x)).Elem()
+		return NewTTypeContext(t.ctx, typx.Underlying(x)).Elem()
 	default:
 		return nil
 	}
@@ -163,7 +171,25 @@ func (t *ttype) Len() int {
 	case *types.Array:
 		return int(x.Len())
 	case *types.Named:
-		return NewTType(typx.Underlying(x)).Len()
+		return NewTTypeContext(t.ctx, typx.Underlying(x)).Len()
+	default:
+		return 0
+	}
+}
+
+func (t *ttype) ChanDir() reflect.ChanDir {
+	switch x := t.t.(type) {
+	case *types.Chan:
+		switch x.Dir() {
+		case types.SendOnly:
+			return reflect.SendDir
+		case types.RecvOnly:
+			return reflect.RecvDir
+		default:
+			return reflect.BothDir
+		}
+	case *types.Named:
+		return NewTTypeContext(t.ctx, x.Underlying()).ChanDir()
 	default:
 		return 0
 	}
@@ -174,7 +200,7 @@ func (t *ttype) NumField() int {
 	case *types.Struct:
 		return x.NumFields()
 	case *types.Named:
-		return NewTType(x.Underlying()).NumField()
+		return NewTTypeContext(t.ctx, x.Underlying()).NumField()
 	default:
 		return 0
 	}
@@ -184,11 +210,11 @@ func (t *ttype) Field(i int) StructField {
 	switch x := t.t.(type) {
 	case *types.Struct:
 		if i >= 0 && i < x.NumFields() {
-			return &TStructField{ctx: t.ctx, v: x.Field(i), tag: x.Tag(i)}
+			return &TStructField{ctx: t.ctx, v: x.Field(i), tag: x.Tag(i), s: x, i: i}
 		}
 		return nil
 	case *types.Named:
-		return NewTType(typx.Underlying(x)).Field(i)
+		return NewTTypeContext(t.ctx, typx.Underlying(x)).Field(i)
 	default:
 		return nil
 	}
@@ -197,7 +223,7 @@ func (t *ttype) Field(i int) StructField {
 func (t *ttype) FieldByName(name string) (StructField, bool) {
 	f := typx.FieldByName(t.t, name)
 	if f != nil {
-		return &TStructField{ctx: t.ctx, v: f.Var(), tag: f.Tag()}, true
+		return &TStructField{ctx: t.ctx, v: f.Var(), tag: f.Tag(), s: f.Struct(), i: f.Index()}, true
 	}
 	return nil, false
 }
@@ -205,7 +231,7 @@ func (t *ttype) FieldByName(name string) (StructField, bool) {
 func (t *ttype) FieldByNameFunc(match func(string) bool) (StructField, bool) {
 	f := typx.FieldByNameFunc(t.t, match)
 	if f != nil {
-		return &TStructField{ctx: t.ctx, v: f.Var(), tag: f.Tag()}, true
+		return &TStructField{ctx: t.ctx, v: f.Var(), tag: f.Tag(), s: f.Struct(), i: f.Index()}, true
 	}
 	return nil, false
 }
@@ -235,7 +261,7 @@ func (t *ttype) IsVariadic() bool {
 	case *types.Signature:
 		return x.Variadic()
 	case *types.Named:
-		return NewTType(x.Underlying()).IsVariadic()
+		return NewTTypeContext(t.ctx, x.Underlying()).IsVariadic()
 	default:
 		return false
 	}
@@ -246,7 +272,7 @@ func (t *ttype) NumIn() int {
 	case *types.Signature:
 		return x.Params().Len()
 	case *types.Named:
-		return NewTType(x.Underlying()).NumIn()
+		return NewTTypeContext(t.ctx, x.Underlying()).NumIn()
 	default:
 		return 0
 	}
@@ -256,11 +282,11 @@ func (t *ttype) In(i int) Type {
 	switch x := t.t.(type) {
 	case *types.Signature:
 		if i >= 0 && i < x.Params().Len() {
-			return NewTType(x.Params().At(i).Type())
+			return NewTTypeContext(t.ctx, x.Params().At(i).Type())
 		}
 		return nil
 	case *types.Named:
-		return NewTType(x.Underlying()).In(i)
+		return NewTTypeContext(t.ctx, x.Underlying()).In(i)
 	default:
 		return nil
 	}
@@ -271,7 +297,7 @@ func (t *ttype) NumOut() int {
 	case *types.Signature:
 		return x.Results().Len()
 	case *types.Named:
-		return NewTType(x.Underlying()).NumOut()
+		return NewTTypeContext(t.ctx, x.Underlying()).NumOut()
 	default:
 		return 0
 	}
@@ -281,20 +307,69 @@ func (t *ttype) Out(i int) Type {
 	switch x := t.t.(type) {
 	case *types.Signature:
 		if i >= 0 && i < x.Results().Len() {
-			return NewTType(x.Results().At(i).Type())
+			return NewTTypeContext(t.ctx, x.Results().At(i).Type())
 		}
 		return nil
 	case *types.Named:
-		return NewTType(x.Underlying()).Out(i)
+		return NewTTypeContext(t.ctx, x.Underlying()).Out(i)
 	default:
 		return nil
 	}
 }
 
+func (t *ttype) Size() uintptr {
+	return uintptr(sizes(t.ctx).Sizeof(t.t))
+}
+
+func (t *ttype) Align() int {
+	return int(sizes(t.ctx).Alignof(t.t))
+}
+
+// FieldAlign returns the same as Align, they are identical in gc compiler
+func (t *ttype) FieldAlign() int {
+	return t.Align()
+}
+
+// obj returns the declared type name of alias or named type
+func (t *ttype) obj() types.Object {
+	if t.alias != nil {
+		return t.alias.Obj()
+	}
+	if x, ok := t.t.(*types.Named); ok {
+		return x.Obj()
+	}
+	return nil
+}
+
+func (t *ttype) Position() token.Position {
+	if obj := t.obj(); obj != nil {
+		return typx.Position(obj)
+	}
+	return token.Position{}
+}
+
+func (t *ttype) Doc() string {
+	if obj := t.obj(); obj != nil {
+		doc, _ := typx.Doc(obj)
+		return doc
+	}
+	return ""
+}
+
+func (t *ttype) Comment() string {
+	if obj := t.obj(); obj != nil {
+		_, comment := typx.Doc(obj)
+		return comment
+	}
+	return ""
+}
+
 type TStructField struct {
 	ctx context.Context
 	v   *types.Var
 	tag string
+	s   *types.Struct // s is the struct declares this field
+	i   int           // i is the field index in s
 }
 
 func (f *TStructField) Pos() int {
@@ -313,7 +388,7 @@ func (f *TStructField) Name() string {
 }
 
 func (f *TStructField) Type() Type {
-	return NewTType(f.v.Type())
+	return NewTTypeContext(f.ctx, f.v.Type())
 }
 
 func (f *TStructField) Tag() reflect.StructTag {
@@ -324,6 +399,28 @@ func (f *TStructField) Anonymous() bool {
 	return f.v.Anonymous()
 }
 
+func (f *TStructField) Offset() uintptr {
+	fields := make([]*types.Var, f.s.NumFields())
+	for i := range fields {
+		fields[i] = f.s.Field(i)
+	}
+	return uintptr(sizes(f.ctx).Offsetsof(fields)[f.i])
+}
+
+func (f *TStructField) Position() token.Position {
+	return typx.Position(f.v.Origin())
+}
+
+func (f *TStructField) Doc() string {
+	doc, _ := typx.Doc(f.v.Origin())
+	return doc
+}
+
+func (f *TStructField) Comment() string {
+	_, comment := typx.Doc(f.v.Origin())
+	return comment
+}
+
 type TMethod struct {
 	ctx context.Context
 	r   types.Type
@@ -348,7 +445,8 @@ func (m *TMethod) Type() Type {
 	for i := range s.Params().Len() {
 		params = append(params, s.Params().At(i))
 	}
-	return NewTType(
+	return NewTTypeContext(
+		m.ctx,
 		types.NewSignatureType(
 			nil, nil, nil,
 			types.NewTuple(params...),
@@ -357,3 +455,17 @@ func (m *TMethod) Type() Type {
 		),
 	)
 }
+
+func (m *TMethod) Position() token.Position {
+	return typx.Position(m.f.Origin())
+}
+
+func (m *TMethod) Doc() string {
+	doc, _ := typx.Doc(m.f)
+	return doc
+}
+
+func (m *TMethod) Comment() string {
+	_, comment := typx.Doc(m.f)
+	return comment
+}
@@ -0,0 +1,427 @@
+package typx
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NewValue wraps x as Value, x can be a reflect.Value. to Set by path, x should
+// be a pointer, or an addressable reflect.Value.
+func NewValue(x any) *Value {
+	if v, ok := x.(reflect.Value); ok {
+		return &Value{v: v}
+	}
+	return &Value{v: reflect.ValueOf(x)}
+}
+
+// Value is a runtime value paired with its rtype, it can be walked like Type
+// and accessed by path.
+type Value struct {
+	v reflect.Value
+}
+
+func (v *Value) Unwrap() reflect.Value {
+	return v.v
+}
+
+// Type returns rtype of value, it returns nil if value is invalid
+func (v *Value) Type() Type {
+	if !v.v.IsValid() {
+		return nil
+	}
+	return NewRType(v.v.Type())
+}
+
+// ValueVisitor visits values during Value.Walk
+type ValueVisitor interface {
+	// Enter is called before visiting the children of c.Value. the children
+	// are skipped if it returns false.
+	Enter(c *ValueCursor) bool
+	// Leave is called after the children of c.Value were visited. it is called
+	// even if Enter returns false.
+	Leave(c *ValueCursor)
+}
+
+// Inspect walks value and calls f for each value entered. the children are
+// skipped if f returns false.
+func (v *Value) Inspect(f func(*ValueCursor) bool) {
+	v.Walk(valueInspector(f))
+}
+
+type valueInspector func(*ValueCursor) bool
+
+func (f valueInspector) Enter(c *ValueCursor) bool { return f(c) }
+
+func (f valueInspector) Leave(*ValueCursor) {}
+
+// Walk traverses value in depth-first order and visits the dereferenced
+// pointers and interfaces, elements of slices and arrays, entries of maps in
+// sorted key order and fields of structs. the non-nil pointers, maps and
+// slices are identified by address, each address is expanded at most once;
+// later occurrences are visited with Visited set, and Recursive is also set if
+// it is referenced by one of its ancestors. nil pointers, maps and interfaces
+// are visited without children.
+func (v *Value) Walk(visitor ValueVisitor) {
+	if !v.v.IsValid() {
+		return
+	}
+	w := &valueWalker{v: visitor, visited: make(map[address]bool)}
+	w.walk(&ValueCursor{Value: v.v})
+}
+
+// ValueCursor describes the visiting value and its position in walking
+type ValueCursor struct {
+	Value  reflect.Value
+	Parent *ValueCursor
+	// Step from parent to this value
+	Step Step
+	// Key of map entry if Step.Kind is StepEntry
+	Key reflect.Value
+	// Visited reports value was expanded by address before, its children will
+	// not be visited again
+	Visited bool
+	// Recursive reports value is referenced by one of its ancestors
+	Recursive bool
+
+	ref *address
+}
+
+// Type returns rtype of value
+func (c *ValueCursor) Type() Type {
+	return NewRType(c.Value.Type())
+}
+
+// Field returns the struct field if value is reached by StepField
+func (c *ValueCursor) Field() (StructField, bool) {
+	if c.Parent == nil || c.Step.Kind != StepField {
+		return nil, false
+	}
+	return c.Parent.Type().Field(c.Step.Index), true
+}
+
+// Depth returns the depth of cursor, root is 0
+func (c *ValueCursor) Depth() int {
+	d := 0
+	for x := c.Parent; x != nil; x = x.Parent {
+		d++
+	}
+	return d
+}
+
+// String returns the path from root, eg: `a.b[3].c["k"]`. it can be used to
+// Get or Set the value from root if map keys are strings, integers or bools.
+func (c *ValueCursor) String() string {
+	cursors := make([]*ValueCursor, 0, c.Depth())
+	for x := c; x.Parent != nil; x = x.Parent {
+		cursors = append(cursors, x)
+	}
+	slices.Reverse(cursors)
+
+	b := strings.Builder{}
+	for _, x := range cursors {
+		switch x.Step.Kind {
+		case StepField:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(x.Step.Name)
+		case StepIndex:
+			b.WriteString("[" + strconv.Itoa(x.Step.Index) + "]")
+		case StepEntry:
+			if x.Key.Kind() == reflect.String {
+				b.WriteString("[" + strconv.Quote(x.Key.String()) + "]")
+			} else {
+				b.WriteString(fmt.Sprintf("[%v]", x.Key))
+			}
+		}
+	}
+	return b.String()
+}
+
+// address identifies value by its address, type and length
+type address struct {
+	t reflect.Type
+	p uintptr
+	n int
+}
+
+func addressOf(v reflect.Value) *address {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if !v.IsNil() {
+			return &address{t: v.Type(), p: v.Pointer()}
+		}
+	case reflect.Slice:
+		if v.Len() > 0 {
+			return &address{t: v.Type(), p: v.Pointer(), n: v.Len()}
+		}
+	}
+	return nil
+}
+
+type valueWalker struct {
+	v       ValueVisitor
+	visited map[address]bool
+}
+
+func (w *valueWalker) walk(c *ValueCursor) {
+	v := c.Value
+	if c.ref = addressOf(v); c.ref != nil {
+		if w.visited[*c.ref] {
+			c.Visited = true
+			for x := c.Parent; x != nil; x = x.Parent {
+				if x.ref != nil && *x.ref == *c.ref {
+					c.Recursive = true
+					break
+				}
+			}
+		}
+		w.visited[*c.ref] = true
+	}
+
+	defer w.v.Leave(c)
+	if !w.v.Enter(c) || c.Visited {
+		return
+	}
+
+	child := func(v reflect.Value, s Step, key reflect.Value) {
+		w.walk(&ValueCursor{Value: v, Parent: c, Step: s, Key: key})
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			child(v.Elem(), Step{Kind: StepElem}, reflect.Value{})
+		}
+	case reflect.Array, reflect.Slice:
+		for i := range v.Len() {
+			child(v.Index(i), Step{Kind: StepIndex, Index: i}, reflect.Value{})
+		}
+	case reflect.Map:
+		for i, k := range sortedKeys(v) {
+			child(v.MapIndex(k), Step{Kind: StepEntry, Index: i}, k)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			child(v.Field(i), Step{Kind: StepField, Index: i, Name: v.Type().Field(i).Name}, reflect.Value{})
+		}
+	}
+}
+
+// sortedKeys returns keys of map v in order, the keys of basic kinds are
+// compared by value and others are compared by formatted string.
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(x, y reflect.Value) int {
+		switch x.Kind() {
+		case reflect.String:
+			return cmp.Compare(x.String(), y.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(x.Int(), y.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(x.Uint(), y.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(x.Float(), y.Float())
+		default:
+			return cmp.Compare(fmt.Sprint(x), fmt.Sprint(y))
+		}
+	})
+	return keys
+}
+
+// Get returns the value at path, eg: `a.b[3].c["k"]`. pointers and interfaces
+// are dereferenced implicitly.
+func (v *Value) Get(path string) (*Value, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	x := v.v
+	for i, s := range steps {
+		x, err = index(x, s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", prefix(steps[:i+1]), err)
+		}
+	}
+	return &Value{v: x}, nil
+}
+
+// Set sets the value at path to x, nil x sets zero value. x should be
+// assignable to the type of target. the nil pointers and maps on path are
+// allocated, and map entries are written back after their values set.
+func (v *Value) Set(path string, x any) error {
+	steps, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	return set(v.v, steps, 0, x)
+}
+
+func set(v reflect.Value, steps []pathStep, i int, x any) error {
+	for i < len(steps) && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() && (v.Kind() == reflect.Interface || !v.CanSet()) {
+			return fmt.Errorf("failed to set %s: nil %s", prefix(steps[:i]), v.Kind())
+		}
+		if v.Kind() == reflect.Interface {
+			// element of interface is not addressable, set a copy of element
+			// then write back
+			if !v.CanSet() {
+				return fmt.Errorf("failed to set %s: unaddressable value", prefix(steps[:i]))
+			}
+			elem := reflect.New(v.Elem().Type()).Elem()
+			elem.Set(v.Elem())
+			if err := set(elem, steps, i, x); err != nil {
+				return err
+			}
+			v.Set(elem)
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if i == len(steps) {
+		if !v.CanSet() {
+			return fmt.Errorf("failed to set %s: unaddressable or unexported value", prefix(steps))
+		}
+		if x == nil {
+			v.SetZero()
+			return nil
+		}
+		rv, ok := x.(reflect.Value)
+		if !ok {
+			rv = reflect.ValueOf(x)
+		}
+		if !rv.Type().AssignableTo(v.Type()) {
+			return fmt.Errorf("failed to set %s: %s is not assignable to %s", prefix(steps), rv.Type(), v.Type())
+		}
+		v.Set(rv)
+		return nil
+	}
+
+	if v.Kind() != reflect.Map {
+		elem, err := index(v, steps[i])
+		if err != nil {
+			return fmt.Errorf("failed to set %s: %w", prefix(steps[:i+1]), err)
+		}
+		return set(elem, steps, i+1, x)
+	}
+
+	// map elements are not addressable, set a copy of element then write back
+	key, err := mapKey(v.Type().Key(), steps[i])
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", prefix(steps[:i+1]), err)
+	}
+	if v.IsNil() {
+		if !v.CanSet() {
+			return fmt.Errorf("failed to set %s: nil map", prefix(steps[:i]))
+		}
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if e := v.MapIndex(key); e.IsValid() {
+		elem.Set(e)
+	}
+	if err = set(elem, steps, i+1, x); err != nil {
+		return err
+	}
+	v.SetMapIndex(key, elem)
+	return nil
+}
+
+// index returns child of v by step, pointers and interfaces are dereferenced
+func index(v reflect.Value, s pathStep) (reflect.Value, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil %s", v.Kind())
+		}
+		v = v.Elem()
+	}
+
+	if s.field != "" {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("field %s of non-struct type %s", s.field, v.Type())
+		}
+		f := v.FieldByName(s.field)
+		if !f.IsValid() {
+			return reflect.Value{}, fmt.Errorf("no field %s in %s", s.field, v.Type())
+		}
+		return f, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.String:
+		i, err := strconv.Atoi(s.literal)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid index %s of %s", s.literal, v.Type())
+		}
+		if i < 0 || i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("index %d out of range [0,%d)", i, v.Len())
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			return reflect.Value{}, fmt.Errorf("key %s not found", s.literal)
+		}
+		return elem, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("index %s of non-indexable type %s", s.literal, v.Type())
+	}
+}
+
+// mapKey parses literal of step as map key of type t
+func mapKey(t reflect.Type, s pathStep) (reflect.Value, error) {
+	if s.field != "" {
+		return reflect.Value{}, fmt.Errorf("field %s of map type", s.field)
+	}
+	var (
+		x   any
+		err error
+	)
+	switch t.Kind() {
+	case reflect.String:
+		x, err = strconv.Unquote(s.literal)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err = strconv.ParseInt(s.literal, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, err = strconv.ParseUint(s.literal, 10, t.Bits())
+	case reflect.Bool:
+		x, err = strconv.ParseBool(s.literal)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported map key type %s", t)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid key %s of %s", s.literal, t)
+	}
+	return reflect.ValueOf(x).Convert(t), nil
+}
+
+// prefix returns path of steps
+func prefix(steps []pathStep) string {
+	b := strings.Builder{}
+	for _, s := range steps {
+		if s.field == "" {
+			b.WriteString("[" + s.literal + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.field)
+	}
+	if b.Len() == 0 {
+		return "root"
+	}
+	return b.String()
+}
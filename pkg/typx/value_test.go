@@ -0,0 +1,175 @@
+package typx_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+func TestValue(t *testing.T) {
+	server := &testdata.Server{Host: "localhost", Port: 80}
+	c := &testdata.Config{
+		Name:    "root",
+		Servers: []*testdata.Server{server, server},
+		Labels:  map[string]string{"b": "2", "a": "1"},
+		Ports:   map[int]testdata.Server{443: {Host: "secure"}},
+		Extra:   testdata.Server{Host: "extra"},
+	}
+	c.Parent = c
+
+	t.Run("Walk", func(t *testing.T) {
+		entered := make([]string, 0)
+		typx.NewValue(c).Inspect(func(x *typx.ValueCursor) bool {
+			entered = append(entered, fmt.Sprintf("%s: %s %t %t", x, x.Type(), x.Visited, x.Recursive))
+			return true
+		})
+		Expect(t, entered, Equal([]string{
+			": *github.com/xoctopus/typx/testdata.Config false false",
+			": github.com/xoctopus/typx/testdata.Config false false",
+			"Name: string false false",
+			"Servers: []*github.com/xoctopus/typx/testdata.Server false false",
+			"Servers[0]: *github.com/xoctopus/typx/testdata.Server false false",
+			"Servers[0]: github.com/xoctopus/typx/testdata.Server false false",
+			"Servers[0].Host: string false false",
+			"Servers[0].Port: int false false",
+			"Servers[1]: *github.com/xoctopus/typx/testdata.Server true false",
+			"Labels: map[string]string false false",
+			`Labels["a"]: string false false`,
+			`Labels["b"]: string false false`,
+			"Ports: map[int]github.com/xoctopus/typx/testdata.Server false false",
+			"Ports[443]: github.com/xoctopus/typx/testdata.Server false false",
+			"Ports[443].Host: string false false",
+			"Ports[443].Port: int false false",
+			"Extra: interface {} false false",
+			"Extra: github.com/xoctopus/typx/testdata.Server false false",
+			"Extra.Host: string false false",
+			"Extra.Port: int false false",
+			"Parent: *github.com/xoctopus/typx/testdata.Config true true",
+			"limit: int false false",
+		}))
+
+		left := 0
+		typx.NewValue(c).Walk(&valueRecorder{left: &left})
+		Expect(t, left, Equal(len(entered)))
+
+		tags := make([]string, 0)
+		typx.NewValue(reflect.ValueOf(*server)).Inspect(func(x *typx.ValueCursor) bool {
+			if f, ok := x.Field(); ok {
+				tags = append(tags, f.Tag().Get("json"))
+			}
+			return true
+		})
+		Expect(t, tags, Equal([]string{"host", "port"}))
+
+		entered = entered[:0]
+		typx.NewValue(reflect.Value{}).Inspect(func(x *typx.ValueCursor) bool {
+			entered = append(entered, x.String())
+			return true
+		})
+		Expect(t, len(entered), Equal(0))
+		Expect(t, typx.NewValue(nil).Type(), Equal[typx.Type](nil))
+		Expect(t, typx.NewValue(c).Type().String(), Equal("*github.com/xoctopus/typx/testdata.Config"))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		v := typx.NewValue(c)
+		for path, expect := range map[string]any{
+			"Name":               "root",
+			"Servers[1].Port":    80,
+			`Labels["b"]`:        "2",
+			"Ports[443].Host":    "secure",
+			"Extra.Host":         "extra",
+			"Parent.Parent.Name": "root",
+			"Name[0]":            byte('r'),
+			"":                   c,
+		} {
+			x, err := v.Get(path)
+			Expect(t, err, BeNil[error]())
+			Expect(t, x.Unwrap().Interface(), Equal(expect))
+		}
+
+		for _, path := range []string{
+			"Undefined",
+			"Name.Undefined",
+			"Servers[2]",
+			"Servers[x]",
+			`Labels["c"]`,
+			"Labels[c]",
+			"Ports[x]",
+			"Name[0][0]",
+			"Servers[0",
+			"Servers[]",
+			`Labels["a]`,
+			`Labels["a"x]`,
+			"Name..Host",
+			"[0]x",
+		} {
+			_, err := v.Get(path)
+			Expect(t, err, NotBeNil[error]())
+		}
+
+		_, err := typx.NewValue(&testdata.Config{}).Get("Parent.Name")
+		Expect(t, err, NotBeNil[error]())
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		x := &testdata.Config{Extra: testdata.Server{}}
+		v := typx.NewValue(x)
+		for _, set := range []struct {
+			path  string
+			value any
+		}{
+			{"Name", "set"},
+			{`Labels["k"]`, "v"},
+			{"Ports[8080].Host", "local"},
+			{"Parent.Servers", []*testdata.Server{{}}},
+			{"Parent.Servers[0].Port", 8080},
+			{"Extra.Port", 1},
+		} {
+			Expect(t, v.Set(set.path, set.value), BeNil[error]())
+			got, err := v.Get(set.path)
+			Expect(t, err, BeNil[error]())
+			Expect(t, got.Unwrap().Interface(), Equal(set.value))
+		}
+		Expect(t, x.Ports[8080], Equal(testdata.Server{Host: "local"}))
+		Expect(t, x.Extra, Equal[any](testdata.Server{Port: 1}))
+
+		Expect(t, v.Set("Parent", nil), BeNil[error]())
+		Expect(t, x.Parent, Equal[*testdata.Config](nil))
+		Expect(t, v.Set("Extra", reflect.ValueOf(2)), BeNil[error]())
+		Expect(t, x.Extra, Equal[any](2))
+
+		for path, value := range map[string]any{
+			"Name":       1,
+			"limit":      1,
+			"Undefined":  1,
+			"Extra.Port": 1,
+			"Servers[0]": nil,
+			"Labels[1]":  "1",
+			"Name[0]":    byte('x'),
+			"Name[":      "x",
+		} {
+			Expect(t, v.Set(path, value), NotBeNil[error]())
+		}
+
+		// not addressable
+		v = typx.NewValue(testdata.Config{Extra: testdata.Server{}})
+		for _, path := range []string{"Name", "Extra.Port", "Parent.Name", `Labels["k"]`} {
+			Expect(t, v.Set(path, "x"), NotBeNil[error]())
+		}
+		Expect(t, typx.NewValue(&testdata.Config{}).Set("Extra.Port", 1), NotBeNil[error]())
+	})
+}
+
+type valueRecorder struct {
+	left *int
+}
+
+func (r *valueRecorder) Enter(*typx.ValueCursor) bool { return true }
+
+func (r *valueRecorder) Leave(*typx.ValueCursor) { *r.left++ }
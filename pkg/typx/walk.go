@@ -0,0 +1,228 @@
+package typx
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// Visitor visits types during Walk
+type Visitor interface {
+	// Enter is called before visiting the children of c.Type. the children are
+	// skipped if it returns false.
+	Enter(c *Cursor) bool
+	// Leave is called after the children of c.Type were visited. it is called
+	// even if Enter returns false.
+	Leave(c *Cursor)
+}
+
+// Inspect walks t and calls f for each type entered. the children are skipped
+// if f returns false.
+func Inspect(t Type, f func(*Cursor) bool) {
+	Walk(t, inspector(f))
+}
+
+type inspector func(*Cursor) bool
+
+func (f inspector) Enter(c *Cursor) bool { return f(c) }
+
+func (f inspector) Leave(*Cursor) {}
+
+// Walk traverses t in depth-first order and visits elements, keys, fields,
+// params, results, methods and type arguments. the type arguments are visited
+// only for the types from go/types, since reflect types do not expose them. each declared named type is
+// expanded at most once; later occurrences are visited with Visited set, and
+// Recursive is also set if it references one of its ancestors, so that
+// recursive types such as `BTreeNode[T]` are walked safely. uninstantiated
+// generic types are visited without children.
+func Walk(t Type, v Visitor) {
+	w := &walker{v: v, visited: make(map[string]bool)}
+	w.walk(&Cursor{Type: t})
+}
+
+// StepKind describes how a type is reached from its parent
+type StepKind uint8
+
+const (
+	StepRoot StepKind = iota
+	StepElem
+	StepKey
+	StepField
+	StepIn
+	StepOut
+	StepMethod
+	StepTypeArg
+	// StepIndex reaches element of slice or array value by Index
+	StepIndex
+	// StepEntry reaches value of map entry, see ValueCursor.Key
+	StepEntry
+)
+
+// Step is an edge from parent type to child type
+type Step struct {
+	Kind StepKind
+	// Index of field, param, result, method, type argument, element or map
+	// entry in sorted order
+	Index int
+	// Name of field or method
+	Name string
+}
+
+func (s Step) String() string {
+	switch s.Kind {
+	case StepElem:
+		return "Elem"
+	case StepKey:
+		return "Key"
+	case StepField:
+		return "Field(" + s.Name + ")"
+	case StepIn:
+		return fmt.Sprintf("In(%d)", s.Index)
+	case StepOut:
+		return fmt.Sprintf("Out(%d)", s.Index)
+	case StepMethod:
+		return "Method(" + s.Name + ")"
+	case StepTypeArg:
+		return fmt.Sprintf("TypeArg(%d)", s.Index)
+	case StepIndex:
+		return fmt.Sprintf("Index(%d)", s.Index)
+	case StepEntry:
+		return fmt.Sprintf("Entry(%d)", s.Index)
+	default:
+		return ""
+	}
+}
+
+// Cursor describes the visiting type and its position in walking
+type Cursor struct {
+	Type   Type
+	Parent *Cursor
+	// Step from parent to this type
+	Step Step
+	// Visited reports named type was expanded before, its children will not be
+	// visited again
+	Visited bool
+	// Recursive reports named type is one of its ancestors
+	Recursive bool
+}
+
+// Depth returns the depth of cursor, root is 0
+func (c *Cursor) Depth() int {
+	d := 0
+	for x := c.Parent; x != nil; x = x.Parent {
+		d++
+	}
+	return d
+}
+
+// Path returns the steps from root to this type
+func (c *Cursor) Path() []Step {
+	steps := make([]Step, c.Depth())
+	for x := c; x.Parent != nil; x = x.Parent {
+		steps[x.Depth()-1] = x.Step
+	}
+	return steps
+}
+
+// String returns the path from root, eg: `Field(Left).Elem.TypeArg(0)`
+func (c *Cursor) String() string {
+	steps := c.Path()
+	parts := make([]string, len(steps))
+	for i, s := range steps {
+		parts[i] = s.String()
+	}
+	return strings.Join(parts, ".")
+}
+
+type walker struct {
+	v       Visitor
+	visited map[string]bool
+}
+
+func (w *walker) walk(c *Cursor) {
+	t := c.Type
+	if t == nil {
+		return
+	}
+
+	named := t.Name() != ""
+	if named && t.PkgPath() != "" {
+		id := t.String()
+		if w.visited[id] {
+			c.Visited = true
+			for x := c.Parent; x != nil; x = x.Parent {
+				if x.Type.PkgPath() != "" && x.Type.String() == id {
+					c.Recursive = true
+					break
+				}
+			}
+		}
+		w.visited[id] = true
+	}
+
+	defer w.v.Leave(c)
+	if !w.v.Enter(c) || c.Visited {
+		return
+	}
+	if g, _ := uninstantiated(t); g != nil {
+		// type parameters cannot be represented as Type
+		return
+	}
+
+	child := func(t Type, s Step) {
+		w.walk(&Cursor{Type: t, Parent: c, Step: s})
+	}
+
+	if named {
+		for i, arg := range typeArgs(t) {
+			child(arg, Step{Kind: StepTypeArg, Index: i})
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Pointer, reflect.Slice:
+		child(t.Elem(), Step{Kind: StepElem})
+	case reflect.Map:
+		child(t.Key(), Step{Kind: StepKey})
+		child(t.Elem(), Step{Kind: StepElem})
+	case reflect.Func:
+		for i := range t.NumIn() {
+			child(t.In(i), Step{Kind: StepIn, Index: i})
+		}
+		for i := range t.NumOut() {
+			child(t.Out(i), Step{Kind: StepOut, Index: i})
+		}
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			child(f.Type(), Step{Kind: StepField, Index: i, Name: f.Name()})
+		}
+	}
+
+	if named || t.Kind() == reflect.Interface {
+		for i := range t.NumMethod() {
+			m := t.Method(i)
+			child(m.Type(), Step{Kind: StepMethod, Index: i, Name: m.Name()})
+		}
+	}
+}
+
+// typeArgs returns type arguments of instantiated named type. reflect types do
+// not expose their type arguments, so they are not reported for rtype, which
+// avoids loading packages and mixing backends in walking.
+func typeArgs(t Type) []Type {
+	x, ok := t.(*ttype)
+	if !ok {
+		return nil
+	}
+	n, ok := x.t.(*types.Named)
+	if !ok {
+		return nil
+	}
+	args := make([]Type, n.TypeArgs().Len())
+	for i := range args {
+		args[i] = NewTTypeContext(x.ctx, n.TypeArgs().At(i))
+	}
+	return args
+}
@@ -0,0 +1,40 @@
+package typx
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+)
+
+// unloadable is declared in test file, it cannot be loaded from source
+type unloadable[T any] struct {
+	V T
+}
+
+// wrapped is a Type implemented by neither rtype nor ttype
+type wrapped struct {
+	Type
+}
+
+func TestTypeArgs(t *testing.T) {
+	x := NewRType(reflect.TypeFor[unloadable[int]]())
+	Expect(t, len(typeArgs(x)), Equal(0))
+	Expect(t, len(typeArgs(wrapped{x})), Equal(0))
+
+	v := &counter{}
+	Walk(x, v)
+	// unloadable[int] and field V
+	Expect(t, v.entered, Equal(2))
+}
+
+type counter struct {
+	entered int
+}
+
+func (v *counter) Enter(*Cursor) bool {
+	v.entered++
+	return true
+}
+
+func (v *counter) Leave(*Cursor) {}
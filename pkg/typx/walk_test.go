@@ -0,0 +1,109 @@
+package typx_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	. "github.com/xoctopus/x/testx"
+
+	typi "github.com/xoctopus/typx/internal/typx"
+	"github.com/xoctopus/typx/pkg/typx"
+	"github.com/xoctopus/typx/testdata"
+)
+
+type recorder struct {
+	entered []string
+	left    int
+	skip    func(*typx.Cursor) bool
+}
+
+func (r *recorder) Enter(c *typx.Cursor) bool {
+	r.entered = append(r.entered, fmt.Sprintf("%s: %s %t %t", c, c.Type, c.Visited, c.Recursive))
+	return r.skip == nil || !r.skip(c)
+}
+
+func (r *recorder) Leave(*typx.Cursor) {
+	r.left++
+}
+
+func TestWalk(t *testing.T) {
+	walk := func(r reflect.Type, skip func(*typx.Cursor) bool) [2]*recorder {
+		x := [2]*recorder{{skip: skip}, {skip: skip}}
+		typx.Walk(typx.NewRType(r), x[0])
+		typx.Walk(typx.NewTType(typi.NewTTByRT(r)), x[1])
+		return x
+	}
+
+	t.Run("Recursive", func(t *testing.T) {
+		x := walk(reflect.TypeFor[testdata.BTreeNode[int]](), nil)
+		Expect(t, x[0].left, Equal(len(x[0].entered)))
+
+		node := "github.com/xoctopus/typx/testdata.BTreeNode[int]"
+		Expect(t, x[1].entered[:4], Equal([]string{
+			": " + node + " false false",
+			"TypeArg(0): int false false",
+			"Field(v): int false false",
+			"Field(r): *" + node + " false false",
+		}))
+		Expect(t, x[1].entered[4], Equal("Field(r).Elem: "+node+" true true"))
+		// type arguments of reflect type are not visited
+		Expect(t, x[0].entered, Equal(append(x[1].entered[:1:1], x[1].entered[2:]...)))
+	})
+
+	t.Run("Composite", func(t *testing.T) {
+		x := walk(reflect.TypeFor[map[string][]func(int, ...string) (chan<- [2]bool, error)](), nil)
+		Expect(t, x[0].entered, Equal(x[1].entered))
+		Expect(t, x[0].entered, Equal([]string{
+			": map[string][]func(int, ...string) (chan<- [2]bool, error) false false",
+			"Key: string false false",
+			"Elem: []func(int, ...string) (chan<- [2]bool, error) false false",
+			"Elem.Elem: func(int, ...string) (chan<- [2]bool, error) false false",
+			"Elem.Elem.In(0): int false false",
+			"Elem.Elem.In(1): []string false false",
+			"Elem.Elem.In(1).Elem: string false false",
+			"Elem.Elem.Out(0): chan<- [2]bool false false",
+			"Elem.Elem.Out(0).Elem: [2]bool false false",
+			"Elem.Elem.Out(0).Elem.Elem: bool false false",
+			"Elem.Elem.Out(1): error false false",
+			"Elem.Elem.Out(1).Method(Error): func() string false false",
+			"Elem.Elem.Out(1).Method(Error).Out(0): string false false",
+		}))
+	})
+
+	t.Run("Visited", func(t *testing.T) {
+		x := walk(reflect.TypeFor[struct{ A, B testdata.String }](), nil)
+		Expect(t, x[0].entered, Equal(x[1].entered))
+		Expect(t, x[0].entered, Equal([]string{
+			": struct { A github.com/xoctopus/typx/testdata.String; B github.com/xoctopus/typx/testdata.String } false false",
+			"Field(A): github.com/xoctopus/typx/testdata.String false false",
+			"Field(B): github.com/xoctopus/typx/testdata.String true false",
+		}))
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		x := walk(reflect.TypeFor[testdata.BTreeNode[int]](), func(c *typx.Cursor) bool {
+			return c.Depth() > 0
+		})
+		// root, type argument and fields v, r, l and p
+		Expect(t, len(x[1].entered), Equal(6))
+		Expect(t, x[1].left, Equal(6))
+		// type arguments of reflect type are not visited
+		Expect(t, len(x[0].entered), Equal(5))
+		Expect(t, x[0].left, Equal(5))
+	})
+
+	t.Run("Inspect", func(t *testing.T) {
+		paths := make([][]typx.Step, 0)
+		typx.Inspect(typx.NewRType(reflect.TypeFor[[]map[int]string]()), func(c *typx.Cursor) bool {
+			paths = append(paths, c.Path())
+			return true
+		})
+		Expect(t, paths, Equal([][]typx.Step{
+			{},
+			{{Kind: typx.StepElem}},
+			{{Kind: typx.StepElem}, {Kind: typx.StepKey}},
+			{{Kind: typx.StepElem}, {Kind: typx.StepElem}},
+		}))
+	})
+}
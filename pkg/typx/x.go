@@ -1,6 +1,7 @@
 package typx
 
 import (
+	"go/token"
 	"reflect"
 )
 
@@ -23,6 +24,7 @@ type Type interface {
 	Key() Type
 	Elem() Type
 	Len() int
+	ChanDir() reflect.ChanDir
 
 	NumField() int
 	Field(int) StructField
@@ -38,12 +40,33 @@ type Type interface {
 	In(int) Type
 	NumOut() int
 	Out(int) Type
+
+	// Size returns the number of bytes needed to store a value of the given
+	// type, like unsafe.Sizeof.
+	Size() uintptr
+	// Align returns the alignment in bytes of a value of this type
+	Align() int
+	// FieldAlign returns the alignment in bytes of a value of this type when
+	// used as a field in a struct
+	FieldAlign() int
+
+	// Position returns declared position of named type. it is valid only
+	// when type is loaded from source
+	Position() token.Position
+	// Doc returns doc comment of named type declaration
+	Doc() string
+	// Comment returns line comment of named type declaration
+	Comment() string
 }
 
 type Method interface {
 	PkgPath() string
 	Name() string
 	Type() Type
+
+	Position() token.Position
+	Doc() string
+	Comment() string
 }
 
 type StructField interface {
@@ -52,4 +75,10 @@ type StructField interface {
 	Type() Type
 	Tag() reflect.StructTag
 	Anonymous() bool
+	// Offset returns offset within its declaring struct, in bytes
+	Offset() uintptr
+
+	Position() token.Position
+	Doc() string
+	Comment() string
 }
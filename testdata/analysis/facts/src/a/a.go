@@ -0,0 +1,17 @@
+package a
+
+type Shape interface { // want Shape:`a\.Shape#[0-9a-f]{8} methods\(Area\) ptrMethods\(\)`
+	Area() float64
+}
+
+type Rect struct { // want Rect:`a\.Rect#[0-9a-f]{8} methods\(\) ptrMethods\(Area\)`
+	Width, Height float64
+}
+
+func (r *Rect) Area() float64 { return r.Width * r.Height }
+
+type Page[T any] struct {
+	Items []T
+}
+
+type Alias = Rect
@@ -0,0 +1,10 @@
+package b
+
+import "a"
+
+type Canvas struct { // want Canvas:`b\.Canvas#[0-9a-f]{8} methods\(Area\) ptrMethods\(Area\)`
+	*a.Rect
+	Primary a.Shape
+	Shapes  []a.Shape
+	Pages   a.Page[a.Rect]
+}
@@ -0,0 +1,14 @@
+package c
+
+import (
+	"a"
+	"b"
+)
+
+type Layer struct {
+	*a.Rect          // want `Rect: a\.Rect#[0-9a-f]{8} methods\(\) ptrMethods\(Area\)`
+	Primary a.Shape  // want `Primary: a\.Shape#[0-9a-f]{8} methods\(Area\) ptrMethods\(\)`
+	Canvas  b.Canvas // want `Canvas: b\.Canvas#[0-9a-f]{8} methods\(Area\) ptrMethods\(Area\)`
+	Shapes  []a.Shape
+	Pages   a.Page[a.Rect]
+}
@@ -0,0 +1,62 @@
+package a
+
+import "time"
+
+type Base struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Meta struct {
+	ID      string `json:"id"`
+	Version int
+}
+
+type Label struct {
+	Label string
+}
+
+type Titled struct {
+	Title string `json:"Label"`
+}
+
+// Tagged has no conflicts since shallower or tagged field is dominant
+type Tagged struct {
+	Base
+	Label
+	Titled
+	Name string
+}
+
+type Entity struct {
+	Base
+	Meta        // want `json name "id" of struct field Meta.ID conflicts with Base.ID, both are ignored by encoding/json`
+	name string `json:"name"` // want `struct field name has json tag but is not exported`
+	skip string `json:"-"`
+
+	CreatedAt time.Time  `json:"createdAt,omitempty"` // want `omitempty in json tag has no effect on struct field CreatedAt of struct type time.Time, use omitzero instead`
+	UpdatedAt time.Time  `json:"updatedAt,omitzero" xml:",attr"`
+	Deleted   *time.Time `json:"deleted,omitempty"`
+	Labels    []string   `json:"labels,omitempy" yaml:"labels,flow"` // want `unknown option "omitempy" in json tag of struct field Labels`
+	Owner     string     `db:"owner,pk"`                             // want `unknown option "pk" in db tag of struct field Owner`
+	Note      string     `json:"note," yaml:",,flow"`                // empty options are ignored
+	X, Y      int        `json:"x"`                                  // want `json name "x" of struct field Y conflicts with X, both are ignored by encoding/json`
+}
+
+type Generic[T any] struct {
+	value T `json:"value"`
+}
+
+func (g Generic[T]) Anonymous() any {
+	return struct {
+		value T `json:"value"`
+	}{}
+}
+
+func Anonymous() any {
+	return struct {
+		value int `yaml:"value"` // want `struct field value has yaml tag but is not exported`
+		*Base
+		*Meta // want `json name "id" of struct field Meta.ID conflicts with Base.ID, both are ignored by encoding/json`
+	}{}
+}
@@ -240,6 +240,7 @@ func (fas FieldAssertions) Run(t *testing.T) {
 				Expect(t, r.f.Tag(), Equal(fa.f.Tag))
 				Expect(t, r.f.PkgPath(), Equal(fa.f.PkgPath))
 				Expect(t, r.f.Anonymous(), Equal(fa.f.Anonymous))
+				Expect(t, r.f.Offset(), Equal(fa.f.Offset))
 				Expect(t, r.f.Type().String(), Equal(fa.typ))
 			} else {
 				Expect(t, r.exists, BeFalse())
@@ -346,6 +347,34 @@ func (c *Case) Run(t *testing.T) {
 		Expect(t, c.tt.Comparable(), Equal(expect))
 	})
 
+	t.Run("ChanDir", func(t *testing.T) {
+		expect := reflect.ChanDir(0)
+		if c.r.Kind() == reflect.Chan {
+			expect = c.r.ChanDir()
+		}
+		Expect(t, c.rt.ChanDir(), Equal(expect))
+		Expect(t, c.tt.ChanDir(), Equal(expect))
+	})
+
+	t.Run("Identical", func(t *testing.T) {
+		Expect(t, typx.Identical(c.rt, c.tt), BeTrue())
+		Expect(t, typx.Identical(c.tt, c.rt), BeTrue())
+		Expect(t, typx.IdenticalIgnoreTags(c.rt, c.tt), BeTrue())
+	})
+
+	t.Run("Fingerprint", func(t *testing.T) {
+		Expect(t, typx.Fingerprint(c.rt), Equal(typx.Fingerprint(c.tt)))
+	})
+
+	t.Run("Layout", func(t *testing.T) {
+		Expect(t, c.rt.Size(), Equal(c.r.Size()))
+		Expect(t, c.tt.Size(), Equal(c.r.Size()))
+		Expect(t, c.rt.Align(), Equal(c.r.Align()))
+		Expect(t, c.tt.Align(), Equal(c.r.Align()))
+		Expect(t, c.rt.FieldAlign(), Equal(c.r.FieldAlign()))
+		Expect(t, c.tt.FieldAlign(), Equal(c.r.FieldAlign()))
+	})
+
 	t.Run("Key", func(t *testing.T) {
 		if c.r.Kind() == reflect.Map {
 			Expect(t, c.rt.Key().String(), Equal(c.tt.Key().String()))
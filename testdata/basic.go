@@ -57,3 +57,9 @@ type Basics struct {
 	TypedIntAlias       IntAlias
 	TypedIntAliasAlias  IntAliasAlias
 }
+
+var (
+	DefaultString = String("default")
+	defaultError  Error
+	aliasedInt    IntAliasAlias
+)
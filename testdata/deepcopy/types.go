@@ -0,0 +1,56 @@
+package deepcopy
+
+import (
+	"time"
+
+	"github.com/xoctopus/typx/testdata"
+)
+
+// Tree is a generic recursive type with parent references
+type Tree[T any] struct {
+	Value    T
+	Values   []T
+	Index    map[string][]T
+	Parent   *Tree[T]
+	Children []*Tree[T]
+}
+
+// Department and Employee reference each other
+type Department struct {
+	Name    string
+	Manager *Employee
+	Members []*Employee
+}
+
+type Employee struct {
+	Name       string
+	Department *Department
+}
+
+type Object struct {
+	Meta
+	ID         int64
+	Tags       []string
+	Labels     map[string]*string
+	Matrix     [2][]int
+	Points     [3]Point
+	Nested     map[string][]Point
+	Ptr        *Point
+	PtrPtr     **int
+	Inner      struct{ Values map[string]int }
+	Any        any
+	Func       func()
+	Time       time.Time
+	CreatedAt  *time.Time
+	Serialized testdata.Serialized[string]
+	Tree       *Tree[int]
+	private    []byte
+}
+
+type Meta struct {
+	Annotations map[string]string
+}
+
+type Point struct {
+	X, Y int
+}
@@ -0,0 +1,719 @@
+// Code generated by typx codegen. DO NOT EDIT.
+
+package deepcopy
+
+import (
+	"reflect"
+	"time"
+)
+
+// DeepCopy returns a deep copy of in
+func (in *Tree[T]) DeepCopy() *Tree[T] {
+	return in.deepCopy(make(map[any]any))
+}
+
+func (in *Tree[T]) deepCopy(seen map[any]any) *Tree[T] {
+	if in == nil {
+		return nil
+	}
+	if x, ok := seen[in]; ok {
+		return x.(*Tree[T])
+	}
+	out := new(Tree[T])
+	seen[in] = out
+	in.deepCopyInto(out, seen)
+	return out
+}
+
+// DeepCopyInto copies in into out deeply
+func (in *Tree[T]) DeepCopyInto(out *Tree[T]) {
+	in.deepCopyInto(out, map[any]any{in: out})
+}
+
+func (in *Tree[T]) deepCopyInto(out *Tree[T], seen map[any]any) {
+	*out = *in
+	{
+		in, out := &in.Values, &out.Values
+		if *in != nil {
+			*out = make([]T, len(*in))
+			copy(*out, *in)
+		}
+	}
+	{
+		in, out := &in.Index, &out.Index
+		if *in != nil {
+			m := make(map[string][]T, len(*in))
+			for key, val := range *in {
+				in, out := &val, new([]T)
+				*out = *in
+				if *in != nil {
+					*out = make([]T, len(*in))
+					copy(*out, *in)
+				}
+				m[key] = *out
+			}
+			*out = m
+		}
+	}
+	{
+		in, out := &in.Parent, &out.Parent
+		if *in != nil {
+			*out = (*in).deepCopy(seen)
+		}
+	}
+	{
+		in, out := &in.Children, &out.Children
+		if *in != nil {
+			*out = make([]*Tree[T], len(*in))
+			copy(*out, *in)
+			for i := range *in {
+				in, out := &(*in)[i], &(*out)[i]
+				if *in != nil {
+					*out = (*in).deepCopy(seen)
+				}
+			}
+		}
+	}
+}
+
+// Equal reports whether in and x are deeply equal
+func (in *Tree[T]) Equal(x *Tree[T]) bool {
+	return in.equal(x, make(map[[2]any]bool))
+}
+
+func (in *Tree[T]) equal(x *Tree[T], seen map[[2]any]bool) bool {
+	if in == x {
+		return true
+	}
+	if in == nil || x == nil {
+		return false
+	}
+	if seen[[2]any{in, x}] {
+		return true
+	}
+	seen[[2]any{in, x}] = true
+	a, b := in, x
+	if !reflect.DeepEqual(a.Value, b.Value) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Values, b.Values) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Index, b.Index) {
+		return false
+	}
+	{
+		a, b := &a.Parent, &b.Parent
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if !a.equal(b, seen) {
+				return false
+			}
+		}
+	}
+	{
+		a, b := &a.Children, &b.Children
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for i := range *a {
+			a, b := &(*a)[i], &(*b)[i]
+			if (*a == nil) != (*b == nil) {
+				return false
+			}
+			if *a != nil && *a != *b {
+				a, b := *a, *b
+				if !a.equal(b, seen) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// DeepCopy returns a deep copy of in
+func (in *Department) DeepCopy() *Department {
+	return in.deepCopy(make(map[any]any))
+}
+
+func (in *Department) deepCopy(seen map[any]any) *Department {
+	if in == nil {
+		return nil
+	}
+	if x, ok := seen[in]; ok {
+		return x.(*Department)
+	}
+	out := new(Department)
+	seen[in] = out
+	in.deepCopyInto(out, seen)
+	return out
+}
+
+// DeepCopyInto copies in into out deeply
+func (in *Department) DeepCopyInto(out *Department) {
+	in.deepCopyInto(out, map[any]any{in: out})
+}
+
+func (in *Department) deepCopyInto(out *Department, seen map[any]any) {
+	*out = *in
+	{
+		in, out := &in.Manager, &out.Manager
+		if *in != nil {
+			*out = (*in).deepCopy(seen)
+		}
+	}
+	{
+		in, out := &in.Members, &out.Members
+		if *in != nil {
+			*out = make([]*Employee, len(*in))
+			copy(*out, *in)
+			for i := range *in {
+				in, out := &(*in)[i], &(*out)[i]
+				if *in != nil {
+					*out = (*in).deepCopy(seen)
+				}
+			}
+		}
+	}
+}
+
+// Equal reports whether in and x are deeply equal
+func (in *Department) Equal(x *Department) bool {
+	return in.equal(x, make(map[[2]any]bool))
+}
+
+func (in *Department) equal(x *Department, seen map[[2]any]bool) bool {
+	if in == x {
+		return true
+	}
+	if in == nil || x == nil {
+		return false
+	}
+	if seen[[2]any{in, x}] {
+		return true
+	}
+	seen[[2]any{in, x}] = true
+	a, b := in, x
+	{
+		a, b := &a.Name, &b.Name
+		if *a != *b {
+			return false
+		}
+	}
+	{
+		a, b := &a.Manager, &b.Manager
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if !a.equal(b, seen) {
+				return false
+			}
+		}
+	}
+	{
+		a, b := &a.Members, &b.Members
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for i := range *a {
+			a, b := &(*a)[i], &(*b)[i]
+			if (*a == nil) != (*b == nil) {
+				return false
+			}
+			if *a != nil && *a != *b {
+				a, b := *a, *b
+				if !a.equal(b, seen) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// DeepCopy returns a deep copy of in
+func (in *Employee) DeepCopy() *Employee {
+	return in.deepCopy(make(map[any]any))
+}
+
+func (in *Employee) deepCopy(seen map[any]any) *Employee {
+	if in == nil {
+		return nil
+	}
+	if x, ok := seen[in]; ok {
+		return x.(*Employee)
+	}
+	out := new(Employee)
+	seen[in] = out
+	in.deepCopyInto(out, seen)
+	return out
+}
+
+// DeepCopyInto copies in into out deeply
+func (in *Employee) DeepCopyInto(out *Employee) {
+	in.deepCopyInto(out, map[any]any{in: out})
+}
+
+func (in *Employee) deepCopyInto(out *Employee, seen map[any]any) {
+	*out = *in
+	{
+		in, out := &in.Department, &out.Department
+		if *in != nil {
+			*out = (*in).deepCopy(seen)
+		}
+	}
+}
+
+// Equal reports whether in and x are deeply equal
+func (in *Employee) Equal(x *Employee) bool {
+	return in.equal(x, make(map[[2]any]bool))
+}
+
+func (in *Employee) equal(x *Employee, seen map[[2]any]bool) bool {
+	if in == x {
+		return true
+	}
+	if in == nil || x == nil {
+		return false
+	}
+	if seen[[2]any{in, x}] {
+		return true
+	}
+	seen[[2]any{in, x}] = true
+	a, b := in, x
+	{
+		a, b := &a.Name, &b.Name
+		if *a != *b {
+			return false
+		}
+	}
+	{
+		a, b := &a.Department, &b.Department
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if !a.equal(b, seen) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DeepCopy returns a deep copy of in
+func (in *Object) DeepCopy() *Object {
+	if in == nil {
+		return nil
+	}
+	out := new(Object)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies in into out deeply
+func (in *Object) DeepCopyInto(out *Object) {
+	*out = *in
+	{
+		in, out := &in.Meta, &out.Meta
+		in.DeepCopyInto(out)
+	}
+	{
+		in, out := &in.Tags, &out.Tags
+		if *in != nil {
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	{
+		in, out := &in.Labels, &out.Labels
+		if *in != nil {
+			m := make(map[string]*string, len(*in))
+			for key, val := range *in {
+				in, out := &val, new(*string)
+				*out = *in
+				if *in != nil {
+					*out = new(string)
+					**out = **in
+				}
+				m[key] = *out
+			}
+			*out = m
+		}
+	}
+	{
+		in, out := &in.Matrix, &out.Matrix
+		for i := range *in {
+			in, out := &(*in)[i], &(*out)[i]
+			if *in != nil {
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	{
+		in, out := &in.Nested, &out.Nested
+		if *in != nil {
+			m := make(map[string][]Point, len(*in))
+			for key, val := range *in {
+				in, out := &val, new([]Point)
+				*out = *in
+				if *in != nil {
+					*out = make([]Point, len(*in))
+					copy(*out, *in)
+				}
+				m[key] = *out
+			}
+			*out = m
+		}
+	}
+	{
+		in, out := &in.Ptr, &out.Ptr
+		if *in != nil {
+			*out = new(Point)
+			**out = **in
+		}
+	}
+	{
+		in, out := &in.PtrPtr, &out.PtrPtr
+		if *in != nil {
+			*out = new(*int)
+			**out = **in
+			in, out := *in, *out
+			if *in != nil {
+				*out = new(int)
+				**out = **in
+			}
+		}
+	}
+	{
+		in, out := &in.Inner, &out.Inner
+		{
+			in, out := &in.Values, &out.Values
+			if *in != nil {
+				m := make(map[string]int, len(*in))
+				for key, val := range *in {
+					m[key] = val
+				}
+				*out = m
+			}
+		}
+	}
+	// Any is copied by assignment, its dynamic value is shared
+	{
+		in, out := &in.CreatedAt, &out.CreatedAt
+		if *in != nil {
+			*out = new(time.Time)
+			**out = **in
+		}
+	}
+	{
+		in, out := &in.Tree, &out.Tree
+		if *in != nil {
+			*out = (*in).DeepCopy()
+		}
+	}
+	{
+		in, out := &in.private, &out.private
+		if *in != nil {
+			*out = make([]uint8, len(*in))
+			copy(*out, *in)
+		}
+	}
+}
+
+// Equal reports whether in and x are deeply equal
+func (in *Object) Equal(x *Object) bool {
+	if in == x {
+		return true
+	}
+	if in == nil || x == nil {
+		return false
+	}
+	a, b := in, x
+	{
+		a, b := &a.Meta, &b.Meta
+		if !a.Equal(b) {
+			return false
+		}
+	}
+	{
+		a, b := &a.ID, &b.ID
+		if *a != *b {
+			return false
+		}
+	}
+	{
+		a, b := &a.Tags, &b.Tags
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for i := range *a {
+			a, b := &(*a)[i], &(*b)[i]
+			if *a != *b {
+				return false
+			}
+		}
+	}
+	{
+		a, b := &a.Labels, &b.Labels
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for key, va := range *a {
+			vb, ok := (*b)[key]
+			if !ok {
+				return false
+			}
+			a, b := &va, &vb
+			if (*a == nil) != (*b == nil) {
+				return false
+			}
+			if *a != nil && *a != *b {
+				a, b := *a, *b
+				if *a != *b {
+					return false
+				}
+			}
+		}
+	}
+	{
+		a, b := &a.Matrix, &b.Matrix
+		for i := range *a {
+			a, b := &(*a)[i], &(*b)[i]
+			if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+				return false
+			}
+			for i := range *a {
+				a, b := &(*a)[i], &(*b)[i]
+				if *a != *b {
+					return false
+				}
+			}
+		}
+	}
+	{
+		a, b := &a.Points, &b.Points
+		if *a != *b {
+			return false
+		}
+	}
+	{
+		a, b := &a.Nested, &b.Nested
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for key, va := range *a {
+			vb, ok := (*b)[key]
+			if !ok {
+				return false
+			}
+			a, b := &va, &vb
+			if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+				return false
+			}
+			for i := range *a {
+				a, b := &(*a)[i], &(*b)[i]
+				if *a != *b {
+					return false
+				}
+			}
+		}
+	}
+	{
+		a, b := &a.Ptr, &b.Ptr
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if *a != *b {
+				return false
+			}
+		}
+	}
+	{
+		a, b := &a.PtrPtr, &b.PtrPtr
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if (*a == nil) != (*b == nil) {
+				return false
+			}
+			if *a != nil && *a != *b {
+				a, b := *a, *b
+				if *a != *b {
+					return false
+				}
+			}
+		}
+	}
+	{
+		a, b := &a.Inner, &b.Inner
+		{
+			a, b := &a.Values, &b.Values
+			if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+				return false
+			}
+			for key, va := range *a {
+				vb, ok := (*b)[key]
+				if !ok {
+					return false
+				}
+				a, b := &va, &vb
+				if *a != *b {
+					return false
+				}
+			}
+		}
+	}
+	{
+		a, b := &a.Any, &b.Any
+		if !reflect.DeepEqual(*a, *b) {
+			return false
+		}
+	}
+	{
+		a, b := &a.Func, &b.Func
+		if *a != nil || *b != nil {
+			return false
+		}
+	}
+	{
+		a, b := &a.Time, &b.Time
+		if !(*a).Equal(*b) {
+			return false
+		}
+	}
+	{
+		a, b := &a.CreatedAt, &b.CreatedAt
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if !(*a).Equal(*b) {
+				return false
+			}
+		}
+	}
+	{
+		a, b := &a.Serialized, &b.Serialized
+		if *a != *b {
+			return false
+		}
+	}
+	{
+		a, b := &a.Tree, &b.Tree
+		if (*a == nil) != (*b == nil) {
+			return false
+		}
+		if *a != nil && *a != *b {
+			a, b := *a, *b
+			if !a.Equal(b) {
+				return false
+			}
+		}
+	}
+	{
+		a, b := &a.private, &b.private
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for i := range *a {
+			a, b := &(*a)[i], &(*b)[i]
+			if *a != *b {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DeepCopy returns a deep copy of in
+func (in *Meta) DeepCopy() *Meta {
+	if in == nil {
+		return nil
+	}
+	out := new(Meta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies in into out deeply
+func (in *Meta) DeepCopyInto(out *Meta) {
+	*out = *in
+	{
+		in, out := &in.Annotations, &out.Annotations
+		if *in != nil {
+			m := make(map[string]string, len(*in))
+			for key, val := range *in {
+				m[key] = val
+			}
+			*out = m
+		}
+	}
+}
+
+// Equal reports whether in and x are deeply equal
+func (in *Meta) Equal(x *Meta) bool {
+	if in == x {
+		return true
+	}
+	if in == nil || x == nil {
+		return false
+	}
+	a, b := in, x
+	{
+		a, b := &a.Annotations, &b.Annotations
+		if len(*a) != len(*b) || (*a == nil) != (*b == nil) {
+			return false
+		}
+		for key, va := range *a {
+			vb, ok := (*b)[key]
+			if !ok {
+				return false
+			}
+			a, b := &va, &vb
+			if *a != *b {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DeepCopy returns a deep copy of in
+func (in *Point) DeepCopy() *Point {
+	if in == nil {
+		return nil
+	}
+	out := new(Point)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies in into out deeply
+func (in *Point) DeepCopyInto(out *Point) {
+	*out = *in
+}
+
+// Equal reports whether in and x are deeply equal
+func (in *Point) Equal(x *Point) bool {
+	if in == x {
+		return true
+	}
+	if in == nil || x == nil {
+		return false
+	}
+	return *in == *x
+}
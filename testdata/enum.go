@@ -0,0 +1,19 @@
+package testdata
+
+// Status describes a task status
+type Status int
+
+const (
+	// STATUS_UNKNOWN the status is not set
+	STATUS_UNKNOWN Status = iota
+	STATUS_PENDING        // waiting for scheduling
+	STATUS_RUNNING        // running
+	STATUS_DONE           // done
+	status_invalid
+)
+
+// STATUS_DEFAULT the default status of a new task
+const STATUS_DEFAULT = STATUS_PENDING
+
+// Level has no constants
+type Level uint8
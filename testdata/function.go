@@ -27,6 +27,24 @@ type Functions struct {
 	CompareNamedString Compare[String]
 }
 
+// Compute returns the max value of e
 func (v Max[T]) Compute(e ...T) T {
 	return v(e...)
 }
+
+// Apply calls f with each element of s and collects the results
+func Apply[S ~[]E, E any, R any](s S, f func(E) R) []R {
+	r := make([]R, 0, len(s))
+	for _, e := range s {
+		r = append(r, f(e))
+	}
+	return r
+}
+
+// Sum returns the sum of v
+func Sum(v ...int) (sum int) {
+	for _, x := range v {
+		sum += x
+	}
+	return sum
+}
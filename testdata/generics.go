@@ -1,6 +1,7 @@
 package testdata
 
 import (
+	"fmt"
 	"net"
 )
 
@@ -20,3 +21,12 @@ type Generics[T any] struct {
 	TypedSlice[net.Addr]
 	NoTArg Int
 }
+
+// Counter's type argument can be inferred by core type of constraint
+type Counter[T ~int] struct {
+	n T
+}
+
+func (c Counter[T]) String() string {
+	return fmt.Sprint(c.n)
+}
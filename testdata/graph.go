@@ -0,0 +1,21 @@
+package testdata
+
+// Department and Employee reference each other
+type Department struct {
+	Manager *Employee
+	Members []Employee
+}
+
+type Employee struct {
+	Department *Department
+	Name       String
+	Profile
+}
+
+type Profile struct {
+	Tags map[String]Serialized[string]
+}
+
+func (p Profile) Lookup(tag String) (Serialized[string], error) {
+	return p.Tags[tag], nil
+}
@@ -0,0 +1,74 @@
+package testdata
+
+import "errors"
+
+// Settings is the configuration of App
+type Settings struct {
+	DSN  string
+	Addr string
+}
+
+// NewSettings returns the default Settings
+func NewSettings() Settings {
+	return Settings{DSN: "mem://", Addr: ":80"}
+}
+
+// Store is a key value store
+type Store interface {
+	Get(key string) (string, bool)
+}
+
+// MemStore is an in memory Store
+type MemStore struct {
+	DSN string
+}
+
+// NewMemStore creates MemStore by Settings
+func NewMemStore(s Settings) (*MemStore, error) {
+	if s.DSN == "" {
+		return nil, errors.New("empty dsn")
+	}
+	return &MemStore{DSN: s.DSN}, nil
+}
+
+func (s *MemStore) Get(key string) (string, bool) {
+	return s.DSN + key, true
+}
+
+// Handler serves requests by Store
+type Handler struct {
+	Store Store
+	Tags  []string
+}
+
+// NewHandler creates Handler
+func NewHandler(s Store, tags ...string) *Handler {
+	return &Handler{Store: s, Tags: tags}
+}
+
+// App serves Handler at Settings.Addr
+type App struct {
+	Addr    string
+	Handler *Handler
+}
+
+// NewApp creates App
+func NewApp(s Settings, h *Handler) *App {
+	return &App{Addr: s.Addr, Handler: h}
+}
+
+// Ping and Pong depend on each other
+type (
+	Ping struct{ Pong *Pong }
+	Pong struct{ Ping *Ping }
+)
+
+// NewPing creates Ping
+func NewPing(p *Pong) *Ping {
+	return &Ping{Pong: p}
+}
+
+// NewPong creates Pong
+func NewPong(p *Ping) *Pong {
+	return &Pong{Ping: p}
+}
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	"net"
+	"time"
+)
+
+// Article is used for json schema generation
+type Article struct {
+	ArticleMeta
+	*ArticleAudit
+	articleStatus
+	ID        int64                        `json:"id,string"`
+	Title     string                       `json:"title"`
+	Subtitle  *string                      `json:"subtitle,omitempty"`
+	Ignored   string                       `json:"-"`
+	Dash      string                       `json:"-,"`
+	Words     uint32                       `json:",omitempty"`
+	Score     *float64                     `json:"score,string"`
+	Published bool                         `json:"published"`
+	Content   []byte                       `json:"content"`
+	Digest    [4]byte                      `json:"digest"`
+	Labels    map[string]String            `json:"labels"`
+	Counters  map[int]int                  `json:"counters"`
+	Addrs     map[string]net.Addr          `json:"addrs"`
+	Parent    *Article                     `json:"parent"`
+	Replies   []Article                    `json:"replies,omitzero"`
+	Payload   any                          `json:"payload"`
+	CreatedAt time.Time                    `json:"createdAt"`
+	UpdatedAt *time.Time                   `json:"updatedAt"`
+	Revisions TypedMap[String, ArticleRev] `json:"revisions"`
+	Extra     struct {
+		Note *int `json:"note"`
+	} `json:"extra"`
+	private string
+}
+
+type ArticleMeta struct {
+	Title  string `json:"metaTitle"`
+	Author String `json:"author"`
+	Editor string
+}
+
+type ArticleAudit struct {
+	Title    string
+	Reviewer string `json:"reviewer"`
+	Editor   string
+}
+
+type articleStatus struct {
+	Status int `json:"status"`
+}
+
+type ArticleRev struct {
+	Version int `json:"version"`
+}
@@ -0,0 +1,44 @@
+package testdata
+
+import (
+	"math"
+	"time"
+)
+
+// Shape is implemented by Circle and Rect
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (c Circle) Area() float64 { return math.Pi * c.Radius * c.Radius }
+
+type Rect struct {
+	Kind   string  `json:"kind"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+func (r *Rect) Area() float64 { return r.Width * r.Height }
+
+// Drawing is a request body
+type Drawing struct {
+	Name      string         `json:"name"`
+	Status    Status         `json:"status"`
+	Primary   Shape          `json:"primary"`
+	Shapes    []Shape        `json:"shapes"`
+	Circles   Page[Circle]   `json:"circles"`
+	Tags      [2]string      `json:"tags"`
+	Meta      map[string]int `json:"meta,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// DrawingResponse is a response body
+type DrawingResponse struct {
+	Drawing *Drawing `json:"drawing"`
+	Total   uint32   `json:"total,string"`
+}
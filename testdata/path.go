@@ -0,0 +1,41 @@
+package testdata
+
+type Deployment struct {
+	ObjectMeta `json:"metadata"`
+	Spec       *DeploymentSpec `json:"spec"`
+}
+
+type ObjectMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type DeploymentSpec struct {
+	*Replicas
+	Containers []Container           `json:"containers"`
+	Sidecars   [2]Container          `json:"sidecars"`
+	Selector   map[string]*Container `json:"selector"`
+	Resources
+	Limits
+}
+
+type Replicas struct {
+	Replicas int32 `json:"replicas"`
+}
+
+type Container struct {
+	Image string      `json:"image"`
+	Ports map[int]int `json:"ports"`
+	Args  []string    `json:"args"`
+	Probe any         `json:"probe"`
+}
+
+type Resources struct {
+	CPU    string `json:"cpu"`
+	Memory string
+}
+
+type Limits struct {
+	CPU    string `json:"maxCPU"`
+	Memory string `json:"Memory"`
+}
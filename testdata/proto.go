@@ -0,0 +1,63 @@
+package testdata
+
+import (
+	"time"
+)
+
+// Order is used for proto3 definition generation
+type Order struct {
+	ID        int64 `protobuf:"varint,1,opt,name=id,proto3"`
+	Customer  string
+	Status    Status `protobuf:"varint,5,opt,name=state,proto3"`
+	Level     Level
+	Items     []*OrderItem
+	Labels    map[string]String
+	Counters  map[int32][]byte
+	Note      *string
+	Parent    *Order
+	Payload   []byte
+	CreatedAt time.Time
+	Timeout   time.Duration
+	Page      Page[OrderItem]
+	Shipping  struct {
+		Address string
+		Express bool
+	}
+	Month    time.Month
+	Ignored  string `protobuf:"-"`
+	internal int
+}
+
+type OrderItem struct {
+	SKU      string
+	Quantity uint16
+	Price    float64
+}
+
+// Priority declares the zero constant last
+type Priority int
+
+const (
+	PRIORITY_HIGH Priority = 1
+	PRIORITY_NONE Priority = 0
+)
+
+// Shipment embeds struct pointer
+type Shipment struct {
+	*OrderItem
+	Carrier  string
+	Priority Priority
+}
+
+// ReservedField takes field number reserved by protobuf implementation
+type ReservedField struct {
+	Name string `protobuf:"bytes,19000,opt,name=name,proto3"`
+}
+
+// Overflowed declares enum value out of int32 range
+type Overflowed int64
+
+const (
+	OVERFLOWED_NONE  Overflowed = 0
+	OVERFLOWED_LARGE Overflowed = 1 << 31
+)
@@ -5,7 +5,9 @@ import (
 	"net"
 )
 
+// Tagged has tagged fields
 type Tagged struct {
+	// A is a tagged string field
 	A              string       `json:"a"`
 	B              String       `json:"b"`
 	Namer          fmt.Stringer `json:"-,\"'#{}[]()<>!@#$%^&*_-+=\\|\""`
@@ -26,6 +28,7 @@ type Serialized[T CanBeSerialized] struct {
 	data T
 }
 
+// String returns data as string
 func (v Serialized[T]) String() string {
 	switch data := any(v.data).(type) {
 	case []byte:
@@ -199,3 +202,12 @@ type Structures struct {
 	UnambiguousL2AndL3x2       UnambiguousL2AndL3x2
 	AmbiguousL2AndL3x2AndField AmbiguousL2AndL3x2AndField
 }
+
+// Padded has paddings between fields
+type Padded struct {
+	A bool
+	B int64
+	C bool
+	D int32
+	E struct{}
+}
@@ -0,0 +1,30 @@
+package testdata
+
+import (
+	"time"
+)
+
+// Page is a generic page of items
+type Page[T any] struct {
+	Items []T      `json:"items"`
+	Total int      `json:"total"`
+	Next  *Page[T] `json:"next,omitempty"`
+}
+
+// Task is used for TypeScript declaration generation
+type Task struct {
+	ArticleMeta
+	ID       int64                        `json:"id,string"`
+	Status   Status                       `json:"status"`
+	Level    Level                        `json:"level,omitempty"`
+	Month    time.Month                   `json:"month"`
+	Deadline *time.Time                   `json:"deadline"`
+	Articles Page[Article]                `json:"articles"`
+	Lookup   map[String]*Page[ArticleRev] `json:"lookup"`
+	Tags     Serialized[string]           `json:"tags"`
+	Matrix   [][2]*float64                `json:"matrix"`
+	Inline   struct {
+		Data  []byte `json:"data"`
+		Valid bool   `json:"valid-flag,omitzero"`
+	} `json:"inline"`
+}
@@ -0,0 +1,72 @@
+export interface Task {
+  metaTitle: string;
+  author: String;
+  Editor: string;
+  id: string;
+  status: Status;
+  level?: Level;
+  month: time.Month;
+  deadline: time.Time | null;
+  articles: Page<Article>;
+  lookup: Record<string, Page<ArticleRev> | null>;
+  tags: Serialized<string>;
+  matrix: (number | null)[][];
+  inline: { data: string; "valid-flag"?: boolean };
+}
+
+export type String = string;
+
+export type Status = 0 | 1 | 2 | 3;
+
+export type Level = number;
+
+export interface Page<T> {
+  items: T[];
+  total: number;
+  next?: Page<T> | null;
+}
+
+export interface Article {
+  metaTitle: string;
+  author: String;
+  Title: string;
+  reviewer: string;
+  status: number;
+  id: string;
+  title: string;
+  subtitle?: string | null;
+  "-": string;
+  Words?: number;
+  score: string | null;
+  published: boolean;
+  content: string;
+  digest: number[];
+  labels: Record<string, String>;
+  counters: Record<string, number>;
+  addrs: Record<string, net.Addr>;
+  parent: Article | null;
+  replies?: Article[];
+  payload: unknown;
+  createdAt: time.Time;
+  updatedAt: time.Time | null;
+  revisions: TypedMap<String, ArticleRev>;
+  extra: { note: number | null };
+}
+
+export interface ArticleRev {
+  version: number;
+}
+
+export interface Serialized<T> {}
+
+export type TypedMap<K, V> = Record<string, V>;
+
+export namespace net {
+  export type Addr = unknown;
+}
+
+export namespace time {
+  export type Month = 1 | 2 | 3 | 4 | 5 | 6 | 7 | 8 | 9 | 10 | 11 | 12;
+
+  export type Time = string;
+}
@@ -0,0 +1,16 @@
+package testdata
+
+type Config struct {
+	Name    string            `json:"name"`
+	Servers []*Server         `json:"servers"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Ports   map[int]Server    `json:"ports,omitempty"`
+	Extra   any               `json:"extra,omitempty"`
+	Parent  *Config           `json:"-"`
+	limit   int
+}
+
+type Server struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
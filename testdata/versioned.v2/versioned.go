@@ -0,0 +1,14 @@
+// Package versioned has a dot in its import path, eg: `gopkg.in/yaml.v3`
+package versioned
+
+type Version struct {
+	Major int
+}
+
+func NewVersion() *Version {
+	return &Version{Major: 2}
+}
+
+func (v Version) String() string {
+	return "v2"
+}